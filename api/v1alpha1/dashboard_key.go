@@ -0,0 +1,44 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"regexp"
+	"strings"
+)
+
+// volatileCountPattern matches counters that change between runs, e.g.
+// "(3 failures)", "(12 of 60)" or "[2/5]".
+var volatileCountPattern = regexp.MustCompile(`\s*(\(\d+[^)]*\)|\[\d+/\d+\])`)
+
+// NormalizeTestName strips the volatile counters and collapses the whitespaces
+// of a test name, so the same test keeps the same name across runs.
+func NormalizeTestName(testName string) string {
+	testName = volatileCountPattern.ReplaceAllString(testName, "")
+	return strings.ToLower(strings.Join(strings.Fields(testName), " "))
+}
+
+// TestKey returns the stable identity of a test, used everywhere two runs
+// must agree on "the same test" regardless of the display title.
+func TestKey(dashboard, tab, testName string) string {
+	return strings.Join([]string{dashboard, tab, NormalizeTestName(testName)}, "/")
+}
+
+// TestKey returns the stable identity of a test run of this tab.
+func (d *DashboardTab) TestKey(test *TestResult) string {
+	return TestKey(d.DashboardName, d.TabName, test.TestName)
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTestKey(t *testing.T) {
+	const dashboard, tab = "sig-release-master-blocking", "gce-cos-master-default"
+	tests := []struct {
+		name     string
+		testName string
+		expected string
+	}{
+		{
+			name:     "plain test name",
+			testName: "ci-kubernetes-build.Overall",
+			expected: dashboard + "/" + tab + "/ci-kubernetes-build.overall",
+		},
+		{
+			name:     "failure counts are ignored",
+			testName: "ci-kubernetes-build.Overall (3 failures)",
+			expected: dashboard + "/" + tab + "/ci-kubernetes-build.overall",
+		},
+		{
+			name:     "run ratios are ignored",
+			testName: "[sig-node] Pods should be restarted [2/5]",
+			expected: dashboard + "/" + tab + "/[sig-node] pods should be restarted",
+		},
+		{
+			name:     "whitespaces are collapsed",
+			testName: "  [sig-node]   Pods should be restarted (12 of 60) ",
+			expected: dashboard + "/" + tab + "/[sig-node] pods should be restarted",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dashTab := &DashboardTab{DashboardName: dashboard, TabName: tab}
+			assert.Equal(t, tt.expected, dashTab.TestKey(&TestResult{TestName: tt.testName}))
+			assert.Equal(t, tt.expected, TestKey(dashboard, tab, tt.testName))
+		})
+	}
+}
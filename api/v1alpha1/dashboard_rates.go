@@ -0,0 +1,55 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// FlakeRate returns the ratio of flaky runs over the total runs of the test,
+// between 0 and 1, or 0 when the test has no runs.
+func (t *TestResult) FlakeRate() float64 {
+	return rate(t.FlakeCount, t.TotalRuns)
+}
+
+// FailureRate returns the ratio of failed runs over the total runs of the test,
+// between 0 and 1, or 0 when the test has no runs.
+func (t *TestResult) FailureRate() float64 {
+	return rate(t.FailureCount, t.TotalRuns)
+}
+
+// DurationRegressionFactor is the ratio of the duration over the baseline
+// duration from which a test duration regressed.
+const DurationRegressionFactor = 2
+
+// DurationRatio returns the ratio of the duration over the baseline duration
+// of the test, or 0 when the test has no baseline.
+func (t *TestResult) DurationRatio() float64 {
+	if t.BaselineDurationSeconds <= 0 {
+		return 0
+	}
+	return float64(t.DurationSeconds) / float64(t.BaselineDurationSeconds)
+}
+
+// DurationRegressed reports whether the test took at least
+// DurationRegressionFactor times its baseline duration.
+func (t *TestResult) DurationRegressed() bool {
+	return t.DurationRatio() >= DurationRegressionFactor
+}
+
+func rate(count, total int) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return float64(count) / float64(total)
+}
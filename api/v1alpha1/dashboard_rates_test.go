@@ -0,0 +1,81 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlakeRate(t *testing.T) {
+	tests := []struct {
+		name     string
+		result   TestResult
+		expected float64
+	}{
+		{name: "no runs", result: TestResult{FlakeCount: 3}, expected: 0},
+		{name: "no flakes", result: TestResult{TotalRuns: 10}, expected: 0},
+		{name: "one in ten", result: TestResult{FlakeCount: 1, TotalRuns: 10}, expected: 0.1},
+		{name: "every run", result: TestResult{FlakeCount: 4, TotalRuns: 4}, expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.expected, tt.result.FlakeRate(), 1e-9)
+		})
+	}
+}
+
+func TestFailureRate(t *testing.T) {
+	tests := []struct {
+		name     string
+		result   TestResult
+		expected float64
+	}{
+		{name: "no runs", result: TestResult{FailureCount: 2}, expected: 0},
+		{name: "quarter of the runs", result: TestResult{FailureCount: 1, TotalRuns: 4}, expected: 0.25},
+		{name: "every run", result: TestResult{FailureCount: 6, TotalRuns: 6}, expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.expected, tt.result.FailureRate(), 1e-9)
+		})
+	}
+}
+
+func TestDurationRegressed(t *testing.T) {
+	tests := []struct {
+		name      string
+		result    TestResult
+		ratio     float64
+		regressed bool
+	}{
+		{name: "no baseline", result: TestResult{DurationSeconds: 600}},
+		{name: "stable duration", result: TestResult{DurationSeconds: 660, BaselineDurationSeconds: 600}, ratio: 1.1},
+		{name: "twice the baseline", result: TestResult{DurationSeconds: 1200, BaselineDurationSeconds: 600}, ratio: 2, regressed: true},
+		{name: "faster than the baseline", result: TestResult{DurationSeconds: 300, BaselineDurationSeconds: 600}, ratio: 0.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.ratio, tt.result.DurationRatio(), 0.001)
+			assert.Equal(t, tt.regressed, tt.result.DurationRegressed())
+		})
+	}
+}
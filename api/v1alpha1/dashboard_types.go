@@ -28,6 +28,13 @@ const (
 
 var ERROR_STATUSES = []string{FAILING_STATUS, FLAKY_STATUS}
 
+const (
+	RUN_PASSED    = "PASSED"
+	RUN_FAILED    = "FAILED"
+	RUN_FLAKY     = "FLAKY"
+	RUN_NO_RESULT = "NO_RESULT"
+)
+
 // DashboardSpec defines the desired state of Dashboard.
 type DashboardSpec struct {
 	// DashboardTab is the name of the tab be scrapped from this board
@@ -67,12 +74,33 @@ type DashboardSummary struct {
 
 // DashboardTab represents test results for a specific dashboard tab
 type DashboardTab struct {
-	TabName   string       `json:"tab_name,omitempty"`
-	TabURL    string       `json:"tab_url,omitempty"`
-	BoardHash string       `json:"board_hash"`
-	StateIcon string       `json:"icon"`
-	TabState  string       `json:"state"`
-	TestRuns  []TestResult `json:"tab_tests,omitempty"`
+	TabName       string       `json:"tab_name,omitempty"`
+	TabURL        string       `json:"tab_url,omitempty"`
+	DashboardName string       `json:"dashboard_name,omitempty"`
+	BoardHash     string       `json:"board_hash"`
+	StateIcon     string       `json:"icon"`
+	TabState      string       `json:"state"`
+	TestRuns      []TestResult `json:"tab_tests,omitempty"`
+	// HiddenTests is the number of tests left out of TestRuns by the limit per tab.
+	HiddenTests int `json:"hidden_tests,omitempty"`
+	// FailingTests is the number of distinct failing tests of the tab, counted
+	// before the limit per tab.
+	FailingTests int `json:"failing_tests,omitempty"`
+	// LinkedTests is the number of tests left out of TestRuns for having a bug
+	// linked in TestGrid.
+	LinkedTests int `json:"linked_tests,omitempty"`
+	// AcknowledgedTests is the number of tests left out of TestRuns for being
+	// acknowledged as known broken.
+	AcknowledgedTests int `json:"acknowledged_tests,omitempty"`
+	// RecoveredTests is the number of flaky tests left out of TestRuns for
+	// passing in their most recent runs.
+	RecoveredTests int `json:"recovered_tests,omitempty"`
+	// LastUpdated is the unix time in milliseconds of the last update of the
+	// tab by TestGrid, 0 when unknown.
+	LastUpdated int64 `json:"last_updated,omitempty"`
+	// StatusMessage is the status of the tab written by TestGrid, e.g. 2 of 3
+	// (66.7%) recent columns passed, empty when TestGrid has none.
+	StatusMessage string `json:"status_message,omitempty"`
 }
 
 // TestResult contains details about an individual test run
@@ -83,6 +111,33 @@ type TestResult struct {
 	TriageURL       string `json:"triage_url"`
 	ProwJobURL      string `json:"prow_url"`
 	ErrorMessage    string `json:"error_message"`
+	// FailureCount is the number of failed runs of the test in the TestGrid window.
+	FailureCount int `json:"failure_count,omitempty"`
+	// FlakeCount is the number of flaky runs of the test in the TestGrid window.
+	FlakeCount int `json:"flake_count,omitempty"`
+	// TotalRuns is the number of runs of the test with a result in the TestGrid window.
+	TotalRuns int `json:"total_runs,omitempty"`
+	// RunResults are the results of the most recent runs of the test, newest first.
+	RunResults []RunResult `json:"run_results,omitempty"`
+	// LinkedBugs are the bugs linked to the test in TestGrid, the test is
+	// already triaged when set.
+	LinkedBugs []string `json:"linked_bugs,omitempty"`
+	// Alerting is set when TestGrid raised an alert for the test.
+	Alerting bool `json:"alerting,omitempty"`
+	// DurationSeconds is the duration of the most recent run of the test with
+	// timing data, 0 when TestGrid has none.
+	DurationSeconds int64 `json:"duration_seconds,omitempty"`
+	// BaselineDurationSeconds is the median duration of the older runs of the
+	// test, 0 when there are too few runs to compare.
+	BaselineDurationSeconds int64 `json:"baseline_duration_seconds,omitempty"`
+	// Slow is set when the duration reaches the minimum duration of the filter.
+	Slow bool `json:"slow,omitempty"`
+}
+
+// RunResult is the result of a single run of a test
+type RunResult struct {
+	Timestamp int64  `json:"timestamp"`
+	Result    string `json:"result"`
 }
 
 // +kubebuilder:object:root=true
@@ -147,7 +147,9 @@ func (in *DashboardTab) DeepCopyInto(out *DashboardTab) {
 	if in.TestRuns != nil {
 		in, out := &in.TestRuns, &out.TestRuns
 		*out = make([]TestResult, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -161,9 +163,34 @@ func (in *DashboardTab) DeepCopy() *DashboardTab {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunResult) DeepCopyInto(out *RunResult) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunResult.
+func (in *RunResult) DeepCopy() *RunResult {
+	if in == nil {
+		return nil
+	}
+	out := new(RunResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TestResult) DeepCopyInto(out *TestResult) {
 	*out = *in
+	if in.RunResults != nil {
+		in, out := &in.RunResults, &out.RunResults
+		*out = make([]RunResult, len(*in))
+		copy(*out, *in)
+	}
+	if in.LinkedBugs != nil {
+		in, out := &in.LinkedBugs, &out.LinkedBugs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TestResult.
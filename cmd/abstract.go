@@ -3,82 +3,913 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"net/http"
 	"os"
+	"regexp"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	"sigs.k8s.io/signalhound/api/v1alpha1"
+	"sigs.k8s.io/signalhound/internal/github"
+	"sigs.k8s.io/signalhound/internal/output"
+	"sigs.k8s.io/signalhound/internal/snapshot"
 	"sigs.k8s.io/signalhound/internal/testgrid"
 	"sigs.k8s.io/signalhound/internal/tui"
 )
 
-// abstractCmd represents the abstract command
-var abstractCmd = &cobra.Command{
-	Use:   "abstract",
-	Short: "Summarize the board status and present the flake or failing ones",
-	RunE:  RunAbstract,
+// newTestGridClient builds the TestGrid client used by default.
+func newTestGridClient(ctx context.Context, client *http.Client, concurrency int, requestTimeout, scrapeTimeout time.Duration) testgrid.TestGridClient {
+	tg := testgrid.NewTestGridWithClient(testgrid.URL, client)
+	tg.Context = ctx
+	tg.Concurrency = concurrency
+	tg.RequestTimeout = requestTimeout
+	tg.ScrapeTimeout = scrapeTimeout
+	return tg
 }
 
-var (
-	tg                   = testgrid.NewTestGrid(testgrid.URL)
-	minFailure, minFlake int
-	refreshInterval      int
-	token                string
-)
+// abstractOptions holds the flags of an abstract command invocation
+type abstractOptions struct {
+	minFailure       int
+	minFlake         int
+	minFailureRate   float64
+	minFlakeRate     float64
+	minTotalRuns     int
+	refreshInterval  int
+	projectID        string
+	httpTimeout      int
+	requestTimeout   int
+	githubTimeout    int
+	scrapeTimeout    int
+	maxIdleConns     int
+	keepAlive        int
+	testgridToken    string
+	testgridAuth     string
+	board            string
+	includePassing   bool
+	excludeLinked    bool
+	recoveredRuns    int
+	outputFormat     string
+	outputTemplate   string
+	groupBy          string
+	logLevel         string
+	historyRuns      int
+	showHistory      int
+	limitPerTab      int
+	recordDir        string
+	sortBy           string
+	sortOrder        testgrid.Sort
+	issuesOutput     string
+	snapshotDir      string
+	trackingIssue    string
+	fieldNames       map[string]string
+	setFields        map[string]string
+	skipFields       []string
+	templateVars     map[string]string
+	maxIssues        int
+	fileMinSeverity  string
+	maxBodyLength    int
+	fieldsRetries    int
+	fieldsPageSize   int
+	tokens           []string
+	outputFile       string
+	outputDir        string
+	minDuration      int
+	sinceRunID       string
+	currentRelease   []string
+	dashboardRelease bool
+	concurrency      int
+	noTUI            bool
+	summaryOnly      bool
+	issueFingerprint bool
+	refreshJitter    int
+	dashboards       []string
+	maxStaleness     int
+	failOnStale      bool
+	statusMessages   bool
+	minTestsAffected int
+	caCert           string
+	userAgent        string
+	sigMinFailure    map[string]int
+	sigMinFlake      map[string]int
+	dashboardRegex   string
+	ackFile          string
+	retryDashboards  int
+	showAcked        bool
+	acknowledged     testgrid.Acknowledgments
+
+	// newTestGridClient builds the TestGrid client of the run, replaced by a fake in tests.
+	newTestGridClient func(ctx context.Context, client *http.Client, concurrency int, requestTimeout, scrapeTimeout time.Duration) testgrid.TestGridClient
+}
 
 func init() {
-	rootCmd.AddCommand(abstractCmd)
+	rootCmd.AddCommand(newAbstractCmd(&abstractOptions{}))
+}
 
-	abstractCmd.PersistentFlags().IntVarP(&minFailure, "min-failure", "f", 0,
+// newAbstractCmd returns the abstract command binding its flags to the options.
+func newAbstractCmd(o *abstractOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "abstract",
+		Short: "Summarize the board status and present the flake or failing ones",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run(cmd)
+		},
+	}
+	flags := cmd.PersistentFlags()
+
+	flags.IntVarP(&o.minFailure, "min-failure", "f", 0,
 		"minimum threshold for test failures, to disable use 0. Defaults to 0.")
-	abstractCmd.PersistentFlags().IntVarP(&minFlake, "min-flake", "m", 0,
+	flags.IntVarP(&o.minFlake, "min-flake", "m", 0,
 		"minimum threshold for test flakeness, to disable use 0. Defaults to 0.")
-	abstractCmd.PersistentFlags().IntVarP(&refreshInterval, "refresh-interval", "r", 0,
+	flags.IntVar(&o.minTestsAffected, "min-tests-affected", 0,
+		"report the tabs with at least this number of distinct failing tests in a widespread breakage section, as probable infra issues, to disable use 0.")
+	flags.Float64Var(&o.minFailureRate, "min-failure-rate", 0,
+		"minimum ratio of failures over the runs of a test, between 0 and 1 (e.g. 0.25 for 25%), to disable use 0.")
+	flags.Float64Var(&o.minFlakeRate, "min-flake-rate", 0,
+		"minimum ratio of flakes over the runs of a test, between 0 and 1 (e.g. 0.1 for 10%), to disable use 0.")
+	flags.IntVar(&o.minTotalRuns, "min-total-runs", 0,
+		"leave out the tests with fewer runs in the window, before the failure and flake thresholds, to disable use 0.")
+	flags.StringToIntVar(&o.sigMinFailure, "sig-min-failure", nil,
+		"minimum threshold for test failures of the tests of a SIG (e.g. node=3), overriding --min-failure, repeatable. A test of several SIGs uses the lowest value set for its SIGs, --min-failure only when none is set.")
+	flags.StringToIntVar(&o.sigMinFlake, "sig-min-flake", nil,
+		"minimum threshold for test flakeness of the tests of a SIG (e.g. node=5), overriding --min-flake, repeatable. A test of several SIGs uses the lowest value set for its SIGs, --min-flake only when none is set.")
+	flags.IntVarP(&o.refreshInterval, "refresh-interval", "r", 0,
 		"refresh interval in seconds (0 to disable auto-refresh)")
+	flags.IntVar(&o.refreshJitter, "refresh-jitter", 0,
+		"randomize each refresh interval by up to plus or minus this number of seconds, to disable use 0.")
+	flags.StringVar(&o.projectID, "project-id", github.PROJECT_ID,
+		"GitHub project used for draft issues, either the node ID (PVT_...) or the project number.")
+	flags.StringArrayVar(&o.tokens, "token", nil,
+		"GitHub token used for draft issues, repeatable to rotate to the next token when one is rate limited. Prefer SIGNALHOUND_GITHUB_TOKENS, flags are visible in the process list.")
+	flags.StringToStringVar(&o.fieldNames, "field-name", nil,
+		"map a draft issue field role, one of: "+strings.Join(github.FieldRoles, ", ")+", to the project field name or ID (e.g. release=\"Target Version\"), unmapped roles match the default field names.")
+	flags.StringToStringVar(&o.templateVars, "template-var", nil,
+		"variable exposed as .Vars.<key> to the issue title and body templates (e.g. epic=KEP-1234), repeatable.")
+	flags.IntVar(&o.maxIssues, "max-issues", 10,
+		"maximum number of draft issues created at once when filing the selected tests in the TUI, to disable use 0.")
+	flags.StringVar(&o.fileMinSeverity, "file-min-severity", "",
+		"least severe tests filed as draft issues, one of: "+strings.Join(testgrid.SeverityLevels, ", ")+", whatever is shown. Defaults to filing every test.")
+	flags.IntVar(&o.maxBodyLength, "max-body-length", github.DefaultMaxBodyLength,
+		"maximum number of characters of a draft issue body, longer bodies have their error message truncated first, then their end, to disable use 0.")
+	flags.IntVar(&o.fieldsRetries, "fields-retries", defaultFieldsRetries,
+		"number of times the GitHub project fields are queried again when returned empty, to disable use 0.")
+	flags.IntVar(&o.fieldsPageSize, "fields-page-size", github.DefaultFieldsPageSize,
+		fmt.Sprintf("number of GitHub project fields queried, between 1 and %d, raise it for boards with more fields.", github.MaxFieldsPageSize))
+	flags.IntVar(&o.httpTimeout, "http-timeout", int(testgrid.DefaultClientOptions.Timeout.Seconds()),
+		"timeout in seconds for each request made to TestGrid.")
+	flags.IntVar(&o.requestTimeout, "timeout-per-request", 0,
+		"timeout in seconds of each TestGrid fetch, failing only its dashboard or tab, to leave it to --http-timeout use 0.")
+	flags.IntVar(&o.scrapeTimeout, "scrape-timeout", defaultScrapeTimeout,
+		"deadline in seconds of a whole TestGrid scrape, the dashboards and tabs not fetched in time are reported as failed, to disable use 0.")
+	flags.IntVar(&o.githubTimeout, "github-timeout", defaultGitHubTimeout,
+		"timeout in seconds of each GitHub request, separate from the TestGrid timeouts, to disable use 0.")
+	flags.IntVar(&o.maxIdleConns, "max-idle-conns", testgrid.DefaultClientOptions.MaxIdleConns,
+		"maximum number of idle connections kept open to TestGrid.")
+	flags.IntVar(&o.keepAlive, "keepalive", int(testgrid.DefaultClientOptions.KeepAlive.Seconds()),
+		"keep-alive period in seconds for connections to TestGrid.")
+	flags.StringArrayVar(&o.dashboards, "dashboard", nil,
+		"TestGrid dashboard scraped, repeatable. Defaults to "+strings.Join(testgrid.DefaultDashboards, " and ")+" unless --dashboard-regex is set.")
+	flags.StringVar(&o.dashboardRegex, "dashboard-regex", "",
+		"also scrape every TestGrid dashboard whose name matches this regular expression (e.g. sig-release-.*-blocking).")
+	flags.IntVar(&o.concurrency, "concurrency", testgrid.DefaultConcurrency,
+		"number of tabs of a dashboard fetched in parallel from TestGrid, at least 1.")
+	flags.StringVar(&o.testgridToken, "testgrid-token", "",
+		"optional bearer token for authenticated TestGrid endpoints.")
+	flags.StringVar(&o.testgridAuth, "testgrid-auth", "",
+		"optional basic auth credentials in the user:password format for authenticated TestGrid endpoints.")
+	flags.StringVar(&o.caCert, "ca-cert", "",
+		"PEM bundle of certificate authorities trusted by the TestGrid and GitHub clients, in addition to the system ones.")
+	flags.StringVar(&o.userAgent, "user-agent", testgrid.DefaultClientOptions.UserAgent,
+		"User-Agent header of the TestGrid and GitHub requests.")
+	flags.StringVar(&o.recordDir, "record-dir", "",
+		"developer option, write every raw TestGrid response to this folder to reproduce parsing bugs in tests.")
+	flags.StringSliceVar(&o.currentRelease, "current-release", nil,
+		"K8s Release set on draft issues (e.g. v1.35), or "+github.ReleaseAuto+" for the release after the latest stable one. Defaults to the latest release option of the board. "+
+			"Several comma separated releases of an overlap window need a text K8s Release field.")
+	flags.BoolVar(&o.dashboardRelease, "release-from-dashboard", false,
+		"set the K8s Release of draft issues from the version of versioned dashboards like sig-release-1.34-blocking, falling back to the current release.")
+	flags.StringToStringVar(&o.setFields, "set-field", nil,
+		"set a single select field of draft issues, by name or ID, to the option (e.g. Status=Triage), overriding the default options, repeatable.")
+	flags.StringArrayVar(&o.skipFields, "skip-field", nil,
+		"leave a field of draft issues, by name or ID, at the board default (e.g. View), repeatable.")
+	flags.StringVar(&o.board, "board", "",
+		"Testgrid Board set on draft issues (e.g. master-blocking, master-informing), inferred from the test dashboard by default.")
+	flags.BoolVar(&o.includePassing, "include-passing", false,
+		"include passing tabs and tests, by default only failing and flaky tests are shown.")
+	flags.IntVar(&o.minDuration, "min-duration", 0,
+		"flag the tests whose latest run took at least this number of minutes as slow, fetching the test durations, to disable use 0.")
+	flags.IntVar(&o.maxStaleness, "max-staleness", 0,
+		"warn about the tabs TestGrid did not update for more than this number of hours, to disable use 0.")
+	flags.BoolVar(&o.failOnStale, "fail-on-stale", false,
+		"fail instead of warning when a tab is older than --max-staleness.")
+	flags.BoolVar(&o.statusMessages, "status-messages", false,
+		"include the status message TestGrid wrote for each tab (e.g. 2 of 3 recent columns passed) in the report and the TUI.")
+	flags.BoolVar(&o.excludeLinked, "exclude-linked-bugs", false,
+		"leave out the tests with a bug linked in TestGrid, showing only the untriaged ones.")
+	flags.IntVar(&o.recoveredRuns, "hide-recovered-flakes", 0,
+		"leave out the recovered flakes, the flaky tests whose last N runs with a result all passed, N being this number, to disable use 0.")
+	flags.IntVar(&o.retryDashboards, "retry-failed-dashboards", 1,
+		"number of passes fetching again the dashboards failing transiently after the first scrape, to disable use 0.")
+	flags.StringVar(&o.ackFile, "ack-file", "",
+		"JSON file of the acknowledged tests, known broken and tracked elsewhere, left out of the report and the TUI until they expire, see the ack command.")
+	flags.BoolVar(&o.showAcked, "show-acknowledged", false,
+		"include the tests acknowledged in --ack-file.")
+	flags.StringVarP(&o.outputFormat, "output", "o", "",
+		"print the report instead of starting the TUI, or the format of --output-file, one of: "+strings.Join(output.Formats, ", ")+".")
+	flags.StringVar(&o.outputTemplate, "output-template", "",
+		"Go template rendering each finding on its line with --output "+output.FormatTemplate+", e.g. '{{.Dashboard}}\\t{{.TestName}}\\t{{.FailureCount}}'.")
+	flags.StringVar(&o.outputFile, "output-file", "",
+		"write the report to this file in the --output format, "+output.FormatTable+" by default. The TUI still starts and saves the report on exit and on ctrl-s.")
+	flags.StringVar(&o.outputDir, "output-dir", "",
+		"write one report per dashboard to this directory in the --output format, "+output.FormatTable+" by default, with an index of the reports. Like --output-file the TUI still starts.")
+	flags.BoolVar(&o.noTUI, "no-tui", false,
+		"never start the TUI, print the report in the --output format, "+output.FormatTable+" by default.")
+	flags.BoolVar(&o.summaryOnly, "include-dashboards-summary-only", false,
+		"fast mode printing the state of each tab from the dashboard summaries, without fetching their tests, in the --output format, "+output.FormatTable+" by default.")
+	flags.StringVar(&o.groupBy, "group-by", output.GroupByDashboard,
+		"group the printed report by one of: "+strings.Join(output.GroupBys, ", ")+".")
+
+	flags.IntVar(&o.limitPerTab, "limit-per-tab", 0,
+		"maximum number of tests shown per tab, the most failing and flaky first, to disable use 0.")
+	flags.IntVar(&o.historyRuns, "history-runs", 10,
+		fmt.Sprintf("number of recent runs listed in the issue body history block, at most %d, to disable use 0.", testgrid.MaxRunResults))
+	flags.IntVar(&o.showHistory, "show-history", 0,
+		fmt.Sprintf("add a history column to the table and markdown reports with the results of the last runs of each test, at most %d, to disable use 0.", testgrid.MaxRunResults))
+	flags.StringVar(&o.sortBy, "sort", "",
+		"sort the tabs and tests by one of: "+strings.Join(testgrid.SortKeys, ", ")+", with a - prefix for descending (e.g. -failures). Defaults to the fetch order.")
+	flags.StringVar(&o.sinceRunID, "since-run-id", "",
+		"count failures and flakes only from the run with this build ID onward, in the tabs having it. Defaults to the whole TestGrid window.")
+	flags.BoolVar(&o.issueFingerprint, "issue-fingerprint", true,
+		"embed the test key as a hidden <!-- signalhound-key: ... --> comment in the issue body, so it is matched regardless of its title.")
+	flags.StringVar(&o.trackingIssue, "tracking-issue", "",
+		"issue, like kubernetes/sig-release#1234, where a rollup comment of the failing and flaky tests in markdown is posted, then edited on every run. Requires a GitHub token.")
+	flags.StringVar(&o.snapshotDir, "snapshot-dir", "",
+		"directory of the snapshots, written by abstract snapshot, created when missing, and read to set the First Seen date of the draft issues.")
+	flags.StringVar(&o.issuesOutput, "issues-output", "",
+		"file where each created draft issue is appended as a JSON line with its URL, test key and timestamp.")
+	flags.StringVar(&o.logLevel, "log-level", "info",
+		"log level written to stderr, one of: debug, info, warn, error.")
 
-	token = os.Getenv("SIGNALHOUND_GITHUB_TOKEN")
-	if token == "" {
-		token = os.Getenv("GITHUB_TOKEN")
+	cmd.AddCommand(newSnapshotCmd(&snapshotOptions{abstractOptions: o}))
+	return cmd
+}
+
+// defaultScrapeTimeout is the deadline in seconds of a TestGrid scrape.
+const defaultScrapeTimeout = 600
+
+// defaultGitHubTimeout is the timeout in seconds of each GitHub request.
+const defaultGitHubTimeout = 30
+
+// defaultFieldsRetries is the number of times the project fields returned
+// empty by GitHub are queried again.
+const defaultFieldsRetries = 1
+
+// githubTokens returns the GitHub tokens in rotation order, from the --token
+// flags, else from the comma separated SIGNALHOUND_GITHUB_TOKENS, else the
+// single token of the environment.
+func githubTokens(flagTokens []string) []string {
+	tokens := flagTokens
+	if len(tokens) == 0 {
+		tokens = strings.Split(os.Getenv("SIGNALHOUND_GITHUB_TOKENS"), ",")
 	}
+	var nonEmpty []string
+	for _, token := range tokens {
+		if token = strings.TrimSpace(token); token != "" {
+			nonEmpty = append(nonEmpty, token)
+		}
+	}
+	if len(nonEmpty) > 0 {
+		return nonEmpty
+	}
+	if token := os.Getenv("SIGNALHOUND_GITHUB_TOKEN"); token != "" {
+		return []string{token}
+	}
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
+		return []string{token}
+	}
+	return nil
+}
+
+// firstSeen returns when each test was first reported by the snapshots of
+// --snapshot-dir, none without snapshots.
+func (o *abstractOptions) firstSeen() (map[string]time.Time, error) {
+	if o.snapshotDir == "" {
+		return nil, nil
+	}
+	snapshots, err := snapshot.Load(o.snapshotDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		slog.Debug("no snapshot directory, the First Seen dates are not set", "dir", o.snapshotDir)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the snapshots: %w", err)
+	}
+	return snapshot.FirstSeen(snapshots), nil
 }
 
-// FetchTabSummary fetches all dashboard tabs from TestGrid.
-func FetchTabSummary() ([]*v1alpha1.DashboardTab, error) {
-	var dashboardTabs []*v1alpha1.DashboardTab
-	for _, dashboard := range []string{"sig-release-master-blocking", "sig-release-master-informing"} {
-		dashSummaries, err := tg.FetchTabSummary(dashboard, v1alpha1.ERROR_STATUSES)
+// resolveReleases resolves the values of --current-release in order, dropping
+// the duplicates, e.g. auto resolving to a release also given as a version.
+func resolveReleases(ctx context.Context, client *http.Client, values []string) ([]string, error) {
+	var releases []string
+	for _, value := range values {
+		release, err := github.ResolveRelease(ctx, client, value)
 		if err != nil {
 			return nil, err
 		}
-		for _, dashSummary := range dashSummaries {
-			dashTab, err := tg.FetchTabTests(&dashSummary, minFailure, minFlake)
-			if err != nil {
-				fmt.Println(fmt.Errorf("error fetching table : %s", err))
-				continue
+		if release != "" && !slices.Contains(releases, release) {
+			releases = append(releases, release)
+		}
+	}
+	return releases, nil
+}
+
+// githubHTTPClient returns the client of the GitHub requests, sending the
+// User-Agent and trusting the CA bundle when set.
+func githubHTTPClient(timeout time.Duration, caCert, userAgent string) (*http.Client, error) {
+	var transport http.RoundTripper = http.DefaultTransport
+	if caCert != "" {
+		tlsConfig, err := testgrid.CATLSConfig(caCert)
+		if err != nil {
+			return nil, err
+		}
+		clone := http.DefaultTransport.(*http.Transport).Clone()
+		clone.TLSClientConfig = tlsConfig
+		transport = clone
+	}
+	return &http.Client{Timeout: timeout, Transport: testgrid.WithUserAgent(transport, userAgent)}, nil
+}
+
+// FetchTabSummary fetches all tabs of the dashboards from TestGrid. The
+// dashboards failing transiently are fetched again, one more pass up to the
+// retries, while the dashboards not found are not retried. The partial tabs of
+// a dashboard are replaced once a retry returns tabs for it or no longer fails
+// it, a retry failing the whole dashboard keeps them.
+func FetchTabSummary(tg testgrid.TestGridClient, dashboards []string, filter testgrid.FilterOptions, retries int) ([]*v1alpha1.DashboardTab, error) {
+	tabs, err := tg.Summarize(dashboards, filter, nil)
+	for pass := 1; pass <= retries; pass++ {
+		failed := testgrid.FailedDashboards(err)
+		if len(failed) == 0 {
+			break
+		}
+		slog.Info("retrying the failed dashboards", "pass", pass, "dashboards", failed)
+		retried, retryErr := tg.Summarize(failed, filter, nil)
+		stillFailed := testgrid.FailedDashboards(retryErr)
+		replaced := map[string]bool{}
+		for _, dashboard := range failed {
+			replaced[dashboard] = !slices.Contains(stillFailed, dashboard)
+		}
+		for _, tab := range retried {
+			replaced[tab.DashboardName] = true
+		}
+		tabs = slices.DeleteFunc(tabs, func(tab *v1alpha1.DashboardTab) bool {
+			return replaced[tab.DashboardName]
+		})
+		tabs = append(tabs, retried...)
+		// the dashboards not retried only have not found errors
+		var errs []error
+		for _, dashboardErr := range testgrid.DashboardErrors(err) {
+			errs = append(errs, dashboardErr)
+		}
+		err = errors.Join(append(errs, retryErr)...)
+	}
+	if failed := testgrid.FailedDashboards(err); retries > 0 && len(failed) > 0 {
+		slog.Warn("dashboards still failing after the retries", "retries", retries, "dashboards", failed)
+	}
+	filter.Sort.Apply(tabs)
+	return tabs, err
+}
+
+// resolveDashboards returns the dashboards to scrape, the --dashboard flags
+// followed by the TestGrid dashboards matching --dashboard-regex, or the
+// default dashboards when neither is set.
+func (o *abstractOptions) resolveDashboards(tg testgrid.TestGridClient) ([]string, error) {
+	if o.dashboardRegex == "" {
+		if len(o.dashboards) == 0 {
+			return testgrid.DefaultDashboards, nil
+		}
+		return o.dashboards, nil
+	}
+	pattern, err := regexp.Compile(o.dashboardRegex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --dashboard-regex: %w", err)
+	}
+	names, err := tg.ListDashboards()
+	if err != nil {
+		return nil, err
+	}
+	var matched []string
+	for _, name := range names {
+		if pattern.MatchString(name) {
+			matched = append(matched, name)
+		}
+	}
+	if len(matched) == 0 {
+		return nil, fmt.Errorf("--dashboard-regex %q matches none of the %d TestGrid dashboards", o.dashboardRegex, len(names))
+	}
+	dashboards := slices.Clone(o.dashboards)
+	for _, name := range matched {
+		if !slices.Contains(dashboards, name) {
+			dashboards = append(dashboards, name)
+		}
+	}
+	return dashboards, nil
+}
+
+// filterOptions returns the TestGrid filter of the flags.
+func (o *abstractOptions) filterOptions() testgrid.FilterOptions {
+	return testgrid.FilterOptions{
+		MinFailure:        o.minFailure,
+		MinFailureRate:    o.minFailureRate,
+		MinFlake:          o.minFlake,
+		MinFlakeRate:      o.minFlakeRate,
+		MinTotalRuns:      o.minTotalRuns,
+		SIGMinFailure:     o.sigMinFailure,
+		SIGMinFlake:       o.sigMinFlake,
+		IncludePassing:    o.includePassing,
+		LimitPerTab:       o.limitPerTab,
+		Sort:              o.sortOrder,
+		SinceRunID:        strings.TrimSpace(o.sinceRunID),
+		ExcludeLinkedBugs: o.excludeLinked,
+		Acknowledged:      o.acknowledged,
+		RecoveredRuns:     o.recoveredRuns,
+		Durations:         o.minDuration > 0 || o.sortOrder.NeedsDurations(),
+		MinDuration:       time.Duration(o.minDuration) * time.Minute,
+	}
+}
+
+// validate checks the scrape flags, setting up the logger, the sort order and
+// the acknowledgments.
+func (o *abstractOptions) validate() error {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(o.logLevel)); err != nil {
+		return fmt.Errorf("invalid --log-level %q: %w", o.logLevel, err)
+	}
+	if len(o.currentRelease) > 1 && o.dashboardRelease {
+		return errors.New("--release-from-dashboard cannot be combined with several --current-release")
+	}
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level})))
+
+	if o.minFailureRate < 0 || o.minFailureRate > 1 {
+		return fmt.Errorf("--min-failure-rate must be between 0 and 1, got %v", o.minFailureRate)
+	}
+	if o.minFlakeRate < 0 || o.minFlakeRate > 1 {
+		return fmt.Errorf("--min-flake-rate must be between 0 and 1, got %v", o.minFlakeRate)
+	}
+	if o.recoveredRuns < 0 {
+		return fmt.Errorf("--hide-recovered-flakes must not be negative, got %d", o.recoveredRuns)
+	}
+	if o.minTotalRuns < 0 {
+		return fmt.Errorf("--min-total-runs must not be negative, got %d", o.minTotalRuns)
+	}
+	for flag, thresholds := range map[string]map[string]int{"--sig-min-failure": o.sigMinFailure, "--sig-min-flake": o.sigMinFlake} {
+		for sig, threshold := range thresholds {
+			if threshold < 0 {
+				return fmt.Errorf("%s must not be negative, got %d for %s", flag, threshold, sig)
 			}
-			if len(dashTab.TestRuns) > 0 {
-				dashboardTabs = append(dashboardTabs, dashTab)
+		}
+	}
+	var err error
+	if o.sortOrder, err = testgrid.ParseSort(o.sortBy); err != nil {
+		return err
+	}
+	if o.refreshJitter < 0 {
+		return fmt.Errorf("--refresh-jitter must not be negative, got %d", o.refreshJitter)
+	}
+	if o.concurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1, got %d", o.concurrency)
+	}
+	if o.requestTimeout < 0 {
+		return fmt.Errorf("--timeout-per-request must not be negative, got %d", o.requestTimeout)
+	}
+	if o.githubTimeout < 0 {
+		return fmt.Errorf("--github-timeout must not be negative, got %d", o.githubTimeout)
+	}
+	if o.scrapeTimeout < 0 {
+		return fmt.Errorf("--scrape-timeout must not be negative, got %d", o.scrapeTimeout)
+	}
+	if o.limitPerTab < 0 {
+		return fmt.Errorf("--limit-per-tab must not be negative, got %d", o.limitPerTab)
+	}
+	if o.minDuration < 0 {
+		return fmt.Errorf("--min-duration must not be negative, got %d", o.minDuration)
+	}
+	if o.minTestsAffected < 0 {
+		return fmt.Errorf("--min-tests-affected must not be negative, got %d", o.minTestsAffected)
+	}
+	if o.maxStaleness < 0 {
+		return fmt.Errorf("--max-staleness must not be negative, got %d", o.maxStaleness)
+	}
+	if o.failOnStale && o.maxStaleness == 0 {
+		return errors.New("--fail-on-stale requires --max-staleness")
+	}
+	if o.retryDashboards < 0 {
+		return fmt.Errorf("--retry-failed-dashboards must not be negative, got %d", o.retryDashboards)
+	}
+	if o.fieldsRetries < 0 {
+		return fmt.Errorf("--fields-retries must not be negative, got %d", o.fieldsRetries)
+	}
+	if o.fieldsPageSize < 1 || o.fieldsPageSize > github.MaxFieldsPageSize {
+		return fmt.Errorf("--fields-page-size must be between 1 and %d, got %d", github.MaxFieldsPageSize, o.fieldsPageSize)
+	}
+	if o.maxIssues < 0 {
+		return fmt.Errorf("--max-issues must not be negative, got %d", o.maxIssues)
+	}
+	if o.maxBodyLength < 0 {
+		return fmt.Errorf("--max-body-length must not be negative, got %d", o.maxBodyLength)
+	}
+	if o.fileMinSeverity, err = testgrid.ParseSeverity(o.fileMinSeverity); err != nil {
+		return fmt.Errorf("invalid --file-min-severity: %w", err)
+	}
+	if o.historyRuns < 0 || o.historyRuns > testgrid.MaxRunResults {
+		return fmt.Errorf("--history-runs must be between 0 and %d, got %d", testgrid.MaxRunResults, o.historyRuns)
+	}
+	if o.showHistory < 0 || o.showHistory > testgrid.MaxRunResults {
+		return fmt.Errorf("--show-history must be between 0 and %d, got %d", testgrid.MaxRunResults, o.showHistory)
+	}
+	if err := tui.ValidateTemplateVars(o.templateVars); err != nil {
+		return fmt.Errorf("invalid --template-var: %w", err)
+	}
+	if o.ackFile != "" && !o.showAcked {
+		if o.acknowledged, err = loadAcknowledgments(o.ackFile, time.Now()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// testGridClient returns the TestGrid client of the flags, its requests
+// canceled with the context.
+func (o *abstractOptions) testGridClient(ctx context.Context) (testgrid.TestGridClient, error) {
+	client, err := testgrid.NewHTTPClient(testgrid.ClientOptions{
+		Timeout:      time.Duration(o.httpTimeout) * time.Second,
+		MaxIdleConns: o.maxIdleConns,
+		KeepAlive:    time.Duration(o.keepAlive) * time.Second,
+		Token:        o.testgridToken,
+		BasicAuth:    o.testgridAuth,
+		RecordDir:    o.recordDir,
+		CACert:       o.caCert,
+		UserAgent:    o.userAgent,
+	})
+	if err != nil {
+		return nil, err
+	}
+	newClient := o.newTestGridClient
+	if newClient == nil {
+		newClient = newTestGridClient
+	}
+	return newClient(ctx, client, o.concurrency, time.Duration(o.requestTimeout)*time.Second, time.Duration(o.scrapeTimeout)*time.Second), nil
+}
+
+// run starts the main command to scrape TestGrid.
+func (o *abstractOptions) run(cmd *cobra.Command) error {
+	if err := o.validate(); err != nil {
+		return err
+	}
+	if o.outputFile != "" && o.outputDir != "" {
+		return errors.New("--output-file and --output-dir are mutually exclusive")
+	}
+	color, err := colorEnabled()
+	if err != nil {
+		return err
+	}
+	// --no-tui prints the report even on a terminal, in the table format unless
+	// set, and --output-file or --output-dir keeps the TUI while writing the report
+	saved := o.outputFile != "" || o.outputDir != ""
+	format := o.outputFormat
+	if (o.noTUI || saved) && format == "" {
+		format = output.FormatTable
+	}
+	headless := format != "" && (!saved || o.noTUI)
+	if o.outputTemplate != "" && format != output.FormatTemplate {
+		return fmt.Errorf("--output-template requires --output %s", output.FormatTemplate)
+	}
+	reportOptions := output.Options{
+		Format:           format,
+		Template:         o.outputTemplate,
+		GroupBy:          o.groupBy,
+		Color:            color && !saved,
+		StatusMessages:   o.statusMessages,
+		MinTestsAffected: o.minTestsAffected,
+		ShowHistory:      o.showHistory,
+	}
+	if format != "" {
+		if err := reportOptions.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if o.summaryOnly {
+		return o.runSummary(cmd, reportOptions)
+	}
+
+	tg, err := o.testGridClient(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	// issue creation is disabled in the TUI when no GitHub token is available
+	tokens := githubTokens(o.tokens)
+	var gh github.ProjectManagerInterface
+	var scopesErr error
+	if len(tokens) > 0 {
+		githubTimeout := time.Duration(o.githubTimeout) * time.Second
+		githubClient, err := githubHTTPClient(githubTimeout, o.caCert, o.userAgent)
+		if err != nil {
+			return err
+		}
+		releases, err := resolveReleases(cmd.Context(), githubClient, o.currentRelease)
+		if err != nil {
+			return fmt.Errorf("invalid --current-release: %w", err)
+		}
+		release, extraReleases := "", []string(nil)
+		if len(releases) > 0 {
+			release, extraReleases = releases[0], releases[1:]
+		}
+		if gh, err = github.NewProjectManager(cmd.Context(), tokens[0], github.Options{
+			ProjectID:          o.projectID,
+			FieldNames:         o.fieldNames,
+			Release:            release,
+			ExtraReleases:      extraReleases,
+			RotationTokens:     tokens[1:],
+			HTTPClient:         githubClient,
+			EmptyFieldsRetries: o.fieldsRetries,
+
+			ReleaseFromDashboard: o.dashboardRelease,
+			SetFields:            o.setFields,
+			SkipFields:           o.skipFields,
+			Timeout:              githubTimeout,
+			FieldsPageSize:       o.fieldsPageSize,
+			MaxBodyLength:        o.maxBodyLength,
+		}); err != nil {
+			return err
+		}
+		defer logGitHubStats(gh)
+		// a token without the required scopes only fails on the first mutation,
+		// tokens are identified by their position to never log them
+		for i, token := range tokens {
+			if err := github.CheckTokenScopes(cmd.Context(), githubClient, token); err != nil {
+				slog.Warn("draft issues may not be created", "token", i+1, "err", err)
+				if scopesErr == nil {
+					scopesErr = err
+				}
 			}
 		}
 	}
-	return dashboardTabs, nil
+	var trackingIssue github.TrackingIssue
+	if o.trackingIssue != "" {
+		if trackingIssue, err = github.ParseTrackingIssue(o.trackingIssue); err != nil {
+			return err
+		}
+		if gh == nil {
+			return fmt.Errorf("%w to comment on --tracking-issue %s", github.ErrTokenMissing, trackingIssue)
+		}
+	}
+	if o.board != "" {
+		if gh == nil {
+			return fmt.Errorf("%w to validate --board %q", github.ErrTokenMissing, o.board)
+		}
+		if err = gh.ValidateBoard(o.board); err != nil {
+			return err
+		}
+	}
+	if len(o.setFields) > 0 || len(o.skipFields) > 0 {
+		if gh == nil {
+			return fmt.Errorf("%w to validate --set-field and --skip-field", github.ErrTokenMissing)
+		}
+		if err = gh.ValidateFields(); err != nil {
+			return err
+		}
+	}
+
+	dashboards, err := o.resolveDashboards(tg)
+	if err != nil {
+		return err
+	}
+
+	// partial results are still rendered when some dashboards are unavailable
+	filter := o.filterOptions()
+	dashboardTabs, fetchErr := FetchTabSummary(tg, dashboards, filter, o.retryDashboards)
+	if fetchErr != nil && len(dashboardTabs) == 0 {
+		return fetchErr
+	}
+	reportOptions.Dashboards = scrapedDashboards(dashboards, fetchErr)
+	maxStaleness := time.Duration(o.maxStaleness) * time.Hour
+	if maxStaleness > 0 {
+		if err := checkStaleness(cmd.ErrOrStderr(), dashboardTabs, maxStaleness, o.failOnStale); err != nil {
+			return err
+		}
+	}
+
+	if o.trackingIssue != "" {
+		url, err := gh.UpsertRollupComment(trackingIssue, rollupBody(dashboardTabs, reportOptions, time.Now()))
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.ErrOrStderr(), "rollup comment of %s updated: %s\n", trackingIssue, url)
+	}
+
+	if headless {
+		if fetchErr != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "warning: partial results, some sources were unavailable:\n%v\n", fetchErr)
+		}
+		if linked := linkedTests(dashboardTabs); linked > 0 {
+			fmt.Fprintf(cmd.ErrOrStderr(), "%d tests with a linked bug excluded\n", linked)
+		}
+		if acked := acknowledgedTests(dashboardTabs); acked > 0 {
+			fmt.Fprintf(cmd.ErrOrStderr(), "(%d acknowledged, hidden)\n", acked)
+		}
+		if recovered := recoveredTests(dashboardTabs); recovered > 0 {
+			fmt.Fprintf(cmd.ErrOrStderr(), "(%d recovered flakes, hidden)\n", recovered)
+		}
+		if saved {
+			return o.saveReport(dashboardTabs, reportOptions)
+		}
+		return output.Render(cmd.OutOrStdout(), dashboardTabs, reportOptions)
+	}
+
+	firstSeen, err := o.firstSeen()
+	if err != nil {
+		return err
+	}
+	opts := tui.Options{
+		RefreshInterval:  time.Duration(o.refreshInterval) * time.Second,
+		RefreshJitter:    time.Duration(o.refreshJitter) * time.Second,
+		Board:            o.board,
+		HistoryRuns:      o.historyRuns,
+		TemplateVars:     o.templateVars,
+		MaxIssues:        o.maxIssues,
+		FileMinSeverity:  o.fileMinSeverity,
+		MaxStaleness:     maxStaleness,
+		StatusMessages:   o.statusMessages,
+		MinTestsAffected: o.minTestsAffected,
+		NoColor:          !color,
+		IssuesOutput:     o.issuesOutput,
+		Fingerprint:      o.issueFingerprint,
+		FirstSeen:        firstSeen,
+		FetchErr:         fetchErr,
+		GitHubErr:        scopesErr,
+	}
+	if saved {
+		opts.SaveReport = func(tabs []*v1alpha1.DashboardTab) error {
+			return o.saveReport(tabs, reportOptions)
+		}
+	}
+	if o.refreshInterval > 0 {
+		opts.RefreshFunc = func() ([]*v1alpha1.DashboardTab, error) {
+			return FetchTabSummary(tg, dashboards, filter, o.retryDashboards)
+		}
+	}
+
+	return tui.RenderVisual(dashboardTabs, gh, opts)
 }
 
-// RunAbstract starts the main command to scrape TestGrid.
-func RunAbstract(cmd *cobra.Command, args []string) error {
-	dashboardTabs, err := FetchTabSummary()
+// saveReport writes the report to --output-file, or one report per dashboard
+// to --output-dir.
+func (o *abstractOptions) saveReport(tabs []*v1alpha1.DashboardTab, opts output.Options) error {
+	if o.outputDir != "" {
+		_, err := output.WriteDir(o.outputDir, tabs, opts)
+		return err
+	}
+	return output.WriteFile(o.outputFile, tabs, opts)
+}
+
+// runSummary prints the state of the tabs of the dashboard summaries, without
+// their tests, to stdout.
+func (o *abstractOptions) runSummary(cmd *cobra.Command, opts output.Options) error {
+	if o.outputFile != "" || o.outputDir != "" || o.trackingIssue != "" {
+		return errors.New("--include-dashboards-summary-only prints to stdout, it cannot be combined with --output-file, --output-dir or --tracking-issue")
+	}
+	if opts.Format == "" {
+		opts.Format = output.FormatTable
+	}
+	tg, err := o.testGridClient(cmd.Context())
+	if err != nil {
+		return err
+	}
+	dashboards, err := o.resolveDashboards(tg)
 	if err != nil {
 		return err
 	}
+	filter := o.filterOptions()
+	filter.SummaryOnly = true
+	tabs, fetchErr := FetchTabSummary(tg, dashboards, filter, o.retryDashboards)
+	if fetchErr != nil && len(tabs) == 0 {
+		return fetchErr
+	}
+	if fetchErr != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "warning: partial results, some sources were unavailable:\n%v\n", fetchErr)
+	}
+	return output.RenderSummary(cmd.OutOrStdout(), tabs, opts)
+}
 
-	var refreshFunc func() ([]*v1alpha1.DashboardTab, error)
-	if refreshInterval > 0 {
-		refreshFunc = func() ([]*v1alpha1.DashboardTab, error) {
-			return FetchTabSummary()
+// rollupBody renders the rollup comment of the tracking issue, the report in
+// markdown under a marker and the time of the run.
+func rollupBody(tabs []*v1alpha1.DashboardTab, opts output.Options, now time.Time) string {
+	opts.Format = output.FormatMarkdown
+	opts.Color = false
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n## CI signal\n\n_Updated %s by signalhound._\n\n", github.RollupMarker, now.UTC().Format("2006-01-02 15:04 MST"))
+	// the markdown format only fails on a failing writer
+	output.Render(&b, tabs, opts) // nolint:errcheck
+	return b.String()
+}
+
+// checkStaleness warns about the tabs TestGrid did not update for longer than
+// the max staleness, or fails on them.
+func checkStaleness(w io.Writer, tabs []*v1alpha1.DashboardTab, maxStaleness time.Duration, fail bool) error {
+	now := time.Now()
+	stale := testgrid.StaleTabs(tabs, maxStaleness, now)
+	if len(stale) == 0 {
+		return nil
+	}
+	if fail {
+		return fmt.Errorf("%d tabs not updated by TestGrid for more than %s, the oldest %s updated %s ago",
+			len(stale), testgrid.FormatAge(maxStaleness), stale[0].BoardHash, testgrid.FormatAge(testgrid.Staleness(stale[0], now)))
+	}
+	fmt.Fprintf(w, "warning: %d tabs not updated by TestGrid for more than %s, their results may be stale:\n", len(stale), testgrid.FormatAge(maxStaleness))
+	for _, tab := range stale {
+		fmt.Fprintf(w, "  %s updated %s ago\n", tab.BoardHash, testgrid.FormatAge(testgrid.Staleness(tab, now)))
+	}
+	return nil
+}
+
+// scrapedDashboards returns the dashboards listed in the report footer, leaving
+// out the ones TestGrid does not serve anymore.
+func scrapedDashboards(dashboards []string, fetchErr error) []string {
+	var scraped []string
+	for _, dashboard := range dashboards {
+		if !slices.ContainsFunc(testgrid.DashboardErrors(fetchErr), func(err *testgrid.DashboardError) bool {
+			return err.Dashboard == dashboard
+		}) {
+			scraped = append(scraped, dashboard)
 		}
 	}
+	return scraped
+}
+
+// linkedTests returns the number of tests excluded from the tabs for having a
+// linked bug.
+func linkedTests(tabs []*v1alpha1.DashboardTab) (linked int) {
+	for _, tab := range tabs {
+		linked += tab.LinkedTests
+	}
+	return linked
+}
+
+// acknowledgedTests returns the number of acknowledged tests left out of the
+// tabs.
+func acknowledgedTests(tabs []*v1alpha1.DashboardTab) (acked int) {
+	for _, tab := range tabs {
+		acked += tab.AcknowledgedTests
+	}
+	return acked
+}
+
+// recoveredTests returns the number of recovered flakes left out of the tabs.
+func recoveredTests(tabs []*v1alpha1.DashboardTab) (recovered int) {
+	for _, tab := range tabs {
+		recovered += tab.RecoveredTests
+	}
+	return recovered
+}
+
+// loadAcknowledgments reads the acknowledgments of the ack file, warning
+// about the expired ones whose tests are shown again.
+func loadAcknowledgments(path string, now time.Time) (testgrid.Acknowledgments, error) {
+	entries, err := testgrid.ReadAckFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading --ack-file: %w", err)
+	}
+	acks, err := testgrid.NewAcknowledgments(entries)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --ack-file %s: %w", path, err)
+	}
+	for _, key := range acks.Expired(now) {
+		slog.Warn("acknowledgment expired, the test is shown again", "test", key)
+	}
+	return acks, nil
+}
 
-	return tui.RenderVisual(dashboardTabs, token, time.Duration(refreshInterval)*time.Second, refreshFunc)
+// logGitHubStats logs the GitHub API calls made during the run at debug level,
+// and the total query cost of the run with the remaining budget at info level.
+func logGitHubStats(gh github.ProjectManagerInterface) {
+	stats := gh.Stats()
+	if stats.QueryCost > 0 {
+		slog.Info("github api query cost", "total_cost", stats.QueryCost, "rate_limit_remaining", stats.RateLimitRemaining)
+	}
+	slog.Debug("github api stats",
+		"queries", stats.Queries,
+		"mutations", stats.Mutations,
+		"field_cache_hits", stats.FieldCacheHits,
+		"field_cache_misses", stats.FieldCacheMisses,
+		"token_rotations", stats.TokenRotations,
+	)
 }
@@ -0,0 +1,558 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"sigs.k8s.io/signalhound/api/v1alpha1"
+	"sigs.k8s.io/signalhound/internal/github"
+	"sigs.k8s.io/signalhound/internal/output"
+	"sigs.k8s.io/signalhound/internal/snapshot"
+	"sigs.k8s.io/signalhound/internal/testgrid"
+)
+
+// fakeTestGrid returns canned tabs from Summarize
+type fakeTestGrid struct {
+	tabs       []*v1alpha1.DashboardTab
+	dashboards []string
+	err        error
+	// summarized are the dashboards of the last Summarize call
+	summarized []string
+	// filter is the filter of the last Summarize call
+	filter testgrid.FilterOptions
+}
+
+func (f *fakeTestGrid) ListDashboards() ([]string, error) {
+	return f.dashboards, f.err
+}
+
+func (f *fakeTestGrid) FetchTabSummary(string, []string) ([]v1alpha1.DashboardSummary, error) {
+	return nil, f.err
+}
+
+func (f *fakeTestGrid) FetchTabTests(*v1alpha1.DashboardSummary, testgrid.FilterOptions) (*v1alpha1.DashboardTab, error) {
+	return nil, f.err
+}
+
+func (f *fakeTestGrid) Summarize(dashboards []string, filter testgrid.FilterOptions, _ chan<- testgrid.ProgressEvent) ([]*v1alpha1.DashboardTab, error) {
+	f.summarized, f.filter = dashboards, filter
+	return f.tabs, f.err
+}
+
+// flakyTestGrid fails the dashboards a number of times before returning their tabs
+type flakyTestGrid struct {
+	fakeTestGrid
+	// failures is the number of failed fetches left by dashboard
+	failures map[string]int
+	// outages is the number of fetches left by dashboard failing the whole
+	// dashboard without tabs, once its failures are exhausted
+	outages map[string]int
+	// calls are the dashboards of each Summarize call
+	calls [][]string
+}
+
+func (f *flakyTestGrid) Summarize(dashboards []string, _ testgrid.FilterOptions, _ chan<- testgrid.ProgressEvent) ([]*v1alpha1.DashboardTab, error) {
+	f.calls = append(f.calls, dashboards)
+	var tabs []*v1alpha1.DashboardTab
+	var errs []error
+	for _, dashboard := range dashboards {
+		if f.failures[dashboard] == 0 && f.outages[dashboard] > 0 {
+			f.outages[dashboard]--
+			errs = append(errs, &testgrid.ScrapeError{Dashboard: dashboard, Err: testgrid.ErrTestGridUnavailable})
+			continue
+		}
+		// a failed dashboard still returns its partial tabs
+		for _, tab := range f.tabs {
+			if tab.DashboardName == dashboard {
+				tabs = append(tabs, tab)
+			}
+		}
+		if f.failures[dashboard] > 0 {
+			f.failures[dashboard]--
+			errs = append(errs, &testgrid.ScrapeError{Dashboard: dashboard, Tab: "flaky", Err: testgrid.ErrTestGridUnavailable})
+		}
+	}
+	return tabs, errors.Join(errs...)
+}
+
+func TestFetchTabSummaryRetries(t *testing.T) {
+	tabs := []*v1alpha1.DashboardTab{
+		{DashboardName: "sig-release-master-blocking", TabName: "build-master"},
+		{DashboardName: "sig-release-master-informing", TabName: "gce-cos-master-serial"},
+	}
+	dashboards := []string{"sig-release-master-blocking", "sig-release-master-informing"}
+	tests := []struct {
+		name       string
+		retries    int
+		failures   map[string]int
+		outages    map[string]int
+		wantCalls  [][]string
+		wantFailed []string
+	}{
+		{name: "no failure", retries: 2, wantCalls: [][]string{dashboards}},
+		{
+			name:      "recovered",
+			retries:   2,
+			failures:  map[string]int{"sig-release-master-informing": 1},
+			wantCalls: [][]string{dashboards, {"sig-release-master-informing"}},
+		},
+		{
+			name:       "still failing",
+			retries:    2,
+			failures:   map[string]int{"sig-release-master-informing": 5},
+			wantCalls:  [][]string{dashboards, {"sig-release-master-informing"}, {"sig-release-master-informing"}},
+			wantFailed: []string{"sig-release-master-informing"},
+		},
+		{
+			name:       "retry failing the whole dashboard",
+			retries:    1,
+			failures:   map[string]int{"sig-release-master-informing": 1},
+			outages:    map[string]int{"sig-release-master-informing": 1},
+			wantCalls:  [][]string{dashboards, {"sig-release-master-informing"}},
+			wantFailed: []string{"sig-release-master-informing"},
+		},
+		{
+			name:       "retries disabled",
+			failures:   map[string]int{"sig-release-master-informing": 1},
+			wantCalls:  [][]string{dashboards},
+			wantFailed: []string{"sig-release-master-informing"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &flakyTestGrid{fakeTestGrid: fakeTestGrid{tabs: tabs}, failures: tt.failures, outages: tt.outages}
+			got, err := FetchTabSummary(fake, dashboards, testgrid.FilterOptions{}, tt.retries)
+			assert.Equal(t, tt.wantCalls, fake.calls)
+			assert.Equal(t, tt.wantFailed, testgrid.FailedDashboards(err))
+			// the partial tabs of the retried dashboards are not duplicated, nor
+			// lost when a retry fails the whole dashboard
+			assert.ElementsMatch(t, tabs, got)
+		})
+	}
+}
+
+// runAbstract runs the abstract command in JSON output mode against the fake.
+func runAbstract(t *testing.T, fake *fakeTestGrid, args ...string) (stdout, stderr string, err error) {
+	t.Helper()
+	t.Setenv("SIGNALHOUND_GITHUB_TOKENS", "")
+	t.Setenv("SIGNALHOUND_GITHUB_TOKEN", "")
+	t.Setenv("GITHUB_TOKEN", "")
+	cmd := newAbstractCmd(&abstractOptions{
+		newTestGridClient: func(context.Context, *http.Client, int, time.Duration, time.Duration) testgrid.TestGridClient {
+			return fake
+		},
+	})
+	var out, errOut bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&errOut)
+	cmd.SetArgs(append([]string{"--output", "json"}, args...))
+	err = cmd.Execute()
+	return out.String(), errOut.String(), err
+}
+
+func TestRunAbstractOutput(t *testing.T) {
+	tabs := []*v1alpha1.DashboardTab{{
+		DashboardName: "sig-release-master-blocking",
+		TabName:       "build-master",
+		TabState:      v1alpha1.FAILING_STATUS,
+		TestRuns:      []v1alpha1.TestResult{{TestName: "ci-kubernetes-build.Overall", FailureCount: 2, TotalRuns: 4}},
+	}}
+	unavailable := errors.New("dashboard sig-release-master-informing: testgrid unavailable")
+	tests := []struct {
+		name    string
+		fake    *fakeTestGrid
+		warning bool
+		rows    int
+		wantErr bool
+	}{
+		{name: "report", fake: &fakeTestGrid{tabs: tabs}, rows: 1},
+		{name: "partial results", fake: &fakeTestGrid{tabs: tabs, err: unavailable}, warning: true, rows: 1},
+		{name: "no results", fake: &fakeTestGrid{err: unavailable}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout, stderr, err := runAbstract(t, tt.fake)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, unavailable)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.warning, strings.Contains(stderr, "partial results"))
+
+			var groups map[string][]json.RawMessage
+			assert.NoError(t, json.Unmarshal([]byte(stdout), &groups))
+			assert.Len(t, groups["sig-release-master-blocking"], tt.rows)
+		})
+	}
+}
+
+func TestRunAbstractLinkedTests(t *testing.T) {
+	tabs := []*v1alpha1.DashboardTab{{DashboardName: "sig-release-master-blocking", LinkedTests: 3}}
+	_, stderr, err := runAbstract(t, &fakeTestGrid{tabs: tabs}, "--exclude-linked-bugs")
+	assert.NoError(t, err)
+	assert.Contains(t, stderr, "3 tests with a linked bug excluded")
+}
+
+func TestRunAbstractOutputTemplate(t *testing.T) {
+	tabs := []*v1alpha1.DashboardTab{{
+		DashboardName: "sig-release-master-blocking",
+		TabName:       "build-master",
+		TestRuns:      []v1alpha1.TestResult{{TestName: "Overall", FailureCount: 2}},
+	}}
+	stdout, _, err := runAbstract(t, &fakeTestGrid{tabs: tabs},
+		"--output", "template", "--output-template", `{{.Dashboard}}\t{{.TestName}}\t{{.FailureCount}}`)
+	assert.NoError(t, err)
+	assert.Equal(t, "sig-release-master-blocking\tOverall\t2\n", stdout)
+
+	// the template is checked before scraping
+	fake := &fakeTestGrid{tabs: tabs}
+	_, _, err = runAbstract(t, fake, "--output", "template", "--output-template", "{{.Test}}")
+	assert.ErrorContains(t, err, "invalid output template")
+	assert.Nil(t, fake.summarized)
+
+	_, _, err = runAbstract(t, fake, "--output-template", "{{.TestName}}")
+	assert.ErrorContains(t, err, "--output-template requires --output template")
+}
+
+func TestRunAbstractRecoveredTests(t *testing.T) {
+	tabs := []*v1alpha1.DashboardTab{{DashboardName: "sig-release-master-blocking", RecoveredTests: 2}}
+	fake := &fakeTestGrid{tabs: tabs}
+	_, stderr, err := runAbstract(t, fake, "--hide-recovered-flakes", "5")
+	assert.NoError(t, err)
+	assert.Contains(t, stderr, "(2 recovered flakes, hidden)")
+	assert.Equal(t, 5, fake.filter.RecoveredRuns)
+}
+
+func TestRunAbstractAckFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "acks.json")
+	assert.NoError(t, testgrid.WriteAckFile(path, []testgrid.AckEntry{
+		{Key: "sig-release-master-blocking/build-master/Overall"},
+		{Key: "sig-release-master-blocking/build-master/kubetest.up", Expires: "2025-10-01"},
+	}))
+	tabs := []*v1alpha1.DashboardTab{{DashboardName: "sig-release-master-blocking", AcknowledgedTests: 1}}
+
+	fake := &fakeTestGrid{tabs: tabs}
+	_, stderr, err := runAbstract(t, fake, "--ack-file", path)
+	assert.NoError(t, err)
+	assert.Contains(t, stderr, "(1 acknowledged, hidden)")
+	assert.True(t, fake.filter.Acknowledged.Acknowledged("sig-release-master-blocking/build-master/overall", time.Now()))
+	assert.Len(t, fake.filter.Acknowledged, 2)
+
+	fake = &fakeTestGrid{}
+	_, _, err = runAbstract(t, fake, "--ack-file", path, "--show-acknowledged")
+	assert.NoError(t, err)
+	assert.Nil(t, fake.filter.Acknowledged)
+
+	_, _, err = runAbstract(t, &fakeTestGrid{}, "--ack-file", filepath.Join(t.TempDir(), "missing.json"))
+	assert.ErrorContains(t, err, "error reading --ack-file")
+}
+
+func TestRunAbstractOutputFile(t *testing.T) {
+	tabs := []*v1alpha1.DashboardTab{{
+		DashboardName: "sig-release-master-blocking",
+		TabName:       "build-master",
+		TestRuns:      []v1alpha1.TestResult{{TestName: "ci-kubernetes-build.Overall"}},
+	}}
+	path := filepath.Join(t.TempDir(), "report.json")
+	stdout, _, err := runAbstract(t, &fakeTestGrid{tabs: tabs}, "--no-tui", "--output-file", path)
+	assert.NoError(t, err)
+	assert.Empty(t, stdout)
+
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "sig-release-master-blocking")
+}
+
+func TestRunAbstractOutputDir(t *testing.T) {
+	tabs := []*v1alpha1.DashboardTab{{
+		DashboardName: "sig-release-master-blocking",
+		TabName:       "build-master",
+		TestRuns:      []v1alpha1.TestResult{{TestName: "ci-kubernetes-build.Overall"}},
+	}}
+	dir := filepath.Join(t.TempDir(), "reports")
+	stdout, _, err := runAbstract(t, &fakeTestGrid{tabs: tabs}, "--no-tui", "--output-dir", dir,
+		"--dashboard", "sig-release-master-blocking", "--dashboard", "sig-release-master-informing")
+	assert.NoError(t, err)
+	assert.Empty(t, stdout)
+
+	for _, name := range []string{"sig-release-master-blocking.json", "sig-release-master-informing.json", "index.json"} {
+		assert.FileExists(t, filepath.Join(dir, name))
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "sig-release-master-blocking.json"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "ci-kubernetes-build.Overall")
+}
+
+func TestRunAbstractDashboards(t *testing.T) {
+	listed := []string{"sig-release-1.34-blocking", "sig-release-master-blocking", "sig-release-master-informing"}
+	tests := []struct {
+		name    string
+		args    []string
+		want    []string
+		wantErr string
+	}{
+		{name: "defaults", want: testgrid.DefaultDashboards},
+		{name: "explicit", args: []string{"--dashboard", "sig-node-release-blocking"}, want: []string{"sig-node-release-blocking"}},
+		{name: "regex", args: []string{"--dashboard-regex", "sig-release-.*-blocking"}, want: []string{"sig-release-1.34-blocking", "sig-release-master-blocking"}},
+		{
+			name: "explicit and regex",
+			args: []string{"--dashboard", "sig-release-master-blocking", "--dashboard", "sig-node-release-blocking", "--dashboard-regex", "blocking$"},
+			want: []string{"sig-release-master-blocking", "sig-node-release-blocking", "sig-release-1.34-blocking"},
+		},
+		{name: "no match", args: []string{"--dashboard-regex", "sig-storage"}, wantErr: "matches none of the 3 TestGrid dashboards"},
+		{name: "invalid regex", args: []string{"--dashboard-regex", "sig-(release"}, wantErr: "invalid --dashboard-regex"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeTestGrid{dashboards: listed}
+			_, _, err := runAbstract(t, fake, tt.args...)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, fake.summarized)
+		})
+	}
+}
+
+func TestRunAbstractDashboardsFooter(t *testing.T) {
+	tabs := []*v1alpha1.DashboardTab{{
+		DashboardName: "sig-release-master-blocking",
+		TabName:       "build-master",
+		TestRuns:      []v1alpha1.TestResult{{TestName: "ci-kubernetes-build.Overall"}},
+	}}
+	notFound := &testgrid.DashboardError{Dashboard: "sig-release-removed", StatusCode: http.StatusNotFound}
+	stdout, _, err := runAbstract(t, &fakeTestGrid{tabs: tabs, err: notFound}, "--output", "table",
+		"--dashboard", "sig-release-master-blocking", "--dashboard", "sig-release-master-informing", "--dashboard", "sig-release-removed")
+	assert.NoError(t, err)
+	assert.Contains(t, stdout, "== Scraped dashboards (2) ==")
+	assert.Contains(t, stdout, "sig-release-master-informing  clean")
+	assert.NotContains(t, stdout, "https://testgrid.k8s.io/sig-release-removed")
+}
+
+func TestRunAbstractStaleness(t *testing.T) {
+	tabs := []*v1alpha1.DashboardTab{
+		{BoardHash: "sig-release-master-blocking#build-master", LastUpdated: time.Now().Add(-30 * time.Hour).UnixMilli()},
+		{BoardHash: "sig-release-master-blocking#verify-master", LastUpdated: time.Now().Add(-time.Hour).UnixMilli()},
+	}
+	tests := []struct {
+		name        string
+		args        []string
+		wantWarning string
+		wantErr     string
+	}{
+		{name: "disabled"},
+		{name: "fresh", args: []string{"--max-staleness", "48"}},
+		{name: "warning", args: []string{"--max-staleness", "6"}, wantWarning: "  sig-release-master-blocking#build-master updated 30h ago\n"},
+		{
+			name:    "fail on stale",
+			args:    []string{"--max-staleness", "6", "--fail-on-stale"},
+			wantErr: "1 tabs not updated by TestGrid for more than 6h, the oldest sig-release-master-blocking#build-master updated 30h ago",
+		},
+		{name: "fail on stale without max staleness", args: []string{"--fail-on-stale"}, wantErr: "--fail-on-stale requires --max-staleness"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, stderr, err := runAbstract(t, &fakeTestGrid{tabs: tabs}, tt.args...)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			if tt.wantWarning == "" {
+				assert.NotContains(t, stderr, "may be stale")
+				return
+			}
+			assert.Contains(t, stderr, tt.wantWarning)
+		})
+	}
+}
+
+func TestRunAbstractSummaryOnly(t *testing.T) {
+	fake := &fakeTestGrid{tabs: []*v1alpha1.DashboardTab{{
+		DashboardName: "sig-release-master-blocking",
+		TabName:       "build-master",
+		TabState:      v1alpha1.FAILING_STATUS,
+	}}}
+	stdout, _, err := runAbstract(t, fake, "--include-dashboards-summary-only")
+	assert.NoError(t, err)
+	assert.True(t, fake.filter.SummaryOnly)
+	assert.Contains(t, stdout, `"summary_only": true`)
+	assert.Contains(t, stdout, `"tab": "build-master"`)
+
+	_, _, err = runAbstract(t, fake, "--include-dashboards-summary-only", "--output-file", filepath.Join(t.TempDir(), "report.json"))
+	assert.ErrorContains(t, err, "cannot be combined")
+}
+
+func TestRunAbstractFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "defaults"},
+		{name: "invalid rate", args: []string{"--min-flake-rate", "2"}, wantErr: "--min-flake-rate"},
+		{name: "invalid min total runs", args: []string{"--min-total-runs", "-1"}, wantErr: "--min-total-runs"},
+		{name: "invalid hide recovered flakes", args: []string{"--hide-recovered-flakes", "-1"}, wantErr: "--hide-recovered-flakes"},
+		{name: "invalid concurrency", args: []string{"--concurrency", "0"}, wantErr: "--concurrency"},
+		{name: "invalid sort", args: []string{"--sort", "age"}, wantErr: "unknown sort"},
+		{name: "invalid min duration", args: []string{"--min-duration", "-5"}, wantErr: "--min-duration"},
+		{name: "invalid fields page size", args: []string{"--fields-page-size", "101"}, wantErr: "--fields-page-size must be between 1 and 100"},
+		{name: "several releases from dashboard", args: []string{"--current-release", "v1.32,v1.31", "--release-from-dashboard"}, wantErr: "--release-from-dashboard cannot be combined"},
+		{name: "invalid max issues", args: []string{"--max-issues", "-1"}, wantErr: "--max-issues"},
+		{name: "invalid show history", args: []string{"--show-history", "51"}, wantErr: "--show-history must be between 0 and 50"},
+		{name: "invalid max body length", args: []string{"--max-body-length", "-1"}, wantErr: "--max-body-length"},
+		{name: "invalid file min severity", args: []string{"--file-min-severity", "urgent"}, wantErr: "invalid --file-min-severity"},
+		{name: "file min severity", args: []string{"--file-min-severity", "High"}},
+		{name: "timeouts", args: []string{"--timeout-per-request", "10", "--scrape-timeout", "0"}},
+		{name: "invalid scrape timeout", args: []string{"--scrape-timeout", "-1"}, wantErr: "--scrape-timeout must not be negative"},
+		{name: "invalid github timeout", args: []string{"--github-timeout", "-1"}, wantErr: "--github-timeout must not be negative"},
+		{name: "invalid tracking issue", args: []string{"--tracking-issue", "kubernetes/sig-release"}, wantErr: "expected owner/repo#number"},
+		{name: "tracking issue without token", args: []string{"--tracking-issue", "kubernetes/sig-release#1"}, wantErr: "to comment on --tracking-issue kubernetes/sig-release#1"},
+		{name: "set field without token", args: []string{"--set-field", "Status=Triage"}, wantErr: "to validate --set-field and --skip-field"},
+		{name: "missing ca cert", args: []string{"--ca-cert", "/nonexistent/ca.pem"}, wantErr: "error reading the CA bundle"},
+		{name: "invalid min tests affected", args: []string{"--min-tests-affected", "-2"}, wantErr: "--min-tests-affected"},
+		{name: "sig thresholds", args: []string{"--sig-min-failure", "node=3", "--sig-min-flake", "sig-storage=5,apps=2"}},
+		{name: "invalid sig threshold", args: []string{"--sig-min-flake", "node=-1"}, wantErr: "--sig-min-flake must not be negative"},
+		{name: "template var", args: []string{"--template-var", "epic=KEP-1234"}},
+		{name: "invalid template var", args: []string{"--template-var", "release-manager=jdoe"}, wantErr: "--template-var"},
+		{name: "output file and dir", args: []string{"--output-file", "report.txt", "--output-dir", "reports"}, wantErr: "mutually exclusive"},
+		{name: "negative fields retries", args: []string{"--fields-retries", "-1"}, wantErr: "--fields-retries must not be negative"},
+		{name: "negative dashboard retries", args: []string{"--retry-failed-dashboards", "-1"}, wantErr: "--retry-failed-dashboards must not be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := runAbstract(t, &fakeTestGrid{tabs: []*v1alpha1.DashboardTab{{DashboardName: "sig-release-master-blocking"}}}, tt.args...)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestRollupBody(t *testing.T) {
+	tabs := []*v1alpha1.DashboardTab{{
+		DashboardName: "sig-release-master-blocking",
+		TabName:       "build-master",
+		TabState:      v1alpha1.FAILING_STATUS,
+		TestRuns:      []v1alpha1.TestResult{{TestName: "ci-kubernetes-build.Overall", FailureCount: 2, TotalRuns: 4}},
+	}}
+	body := rollupBody(tabs, output.Options{Format: output.FormatJSON, Color: true}, time.Date(2025, 10, 1, 12, 30, 0, 0, time.UTC))
+	assert.True(t, strings.HasPrefix(body, github.RollupMarker+"\n## CI signal\n\n_Updated 2025-10-01 12:30 UTC by signalhound._\n\n"))
+	// the report is in markdown whatever the output format
+	assert.Contains(t, body, "ci-kubernetes-build.Overall")
+	assert.Contains(t, body, "|")
+	assert.NotContains(t, body, "\x1b[")
+}
+
+func TestFirstSeen(t *testing.T) {
+	o := &abstractOptions{}
+	firstSeen, err := o.firstSeen()
+	assert.NoError(t, err)
+	assert.Nil(t, firstSeen)
+
+	// no snapshot was written yet
+	o.snapshotDir = filepath.Join(t.TempDir(), "snapshots")
+	firstSeen, err = o.firstSeen()
+	assert.NoError(t, err)
+	assert.Nil(t, firstSeen)
+
+	scraped := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+	_, err = snapshot.Write(o.snapshotDir, snapshot.Snapshot{Time: scraped, Tabs: []*v1alpha1.DashboardTab{{
+		DashboardName: "sig-release-master-blocking",
+		TabName:       "build-master",
+		TestRuns:      []v1alpha1.TestResult{{TestName: "Overall", FailureCount: 2}},
+	}}})
+	assert.NoError(t, err)
+	firstSeen, err = o.firstSeen()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]time.Time{"sig-release-master-blocking/build-master/overall": scraped}, firstSeen)
+}
+
+func TestResolveReleases(t *testing.T) {
+	releases, err := resolveReleases(context.Background(), http.DefaultClient, []string{"v1.32", "", "1.32.1", "v1.31"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1.32", "1.31"}, releases)
+
+	releases, err = resolveReleases(context.Background(), http.DefaultClient, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, releases)
+
+	_, err = resolveReleases(context.Background(), http.DefaultClient, []string{"v1.32", "next"})
+	assert.ErrorContains(t, err, `invalid release "next"`)
+}
+
+func TestGitHubHTTPClient(t *testing.T) {
+	var userAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := githubHTTPClient(time.Second, "", "release-team-bot/1.0")
+	assert.NoError(t, err)
+	response, err := client.Get(server.URL)
+	assert.NoError(t, err)
+	response.Body.Close() // nolint
+	assert.Equal(t, "release-team-bot/1.0", userAgent)
+
+	_, err = githubHTTPClient(time.Second, filepath.Join(t.TempDir(), "missing.pem"), "")
+	assert.Error(t, err)
+}
+
+func TestGitHubTokens(t *testing.T) {
+	tests := []struct {
+		name     string
+		flags    []string
+		env      map[string]string
+		expected []string
+	}{
+		{name: "no token"},
+		{name: "single token", env: map[string]string{"GITHUB_TOKEN": "default"}, expected: []string{"default"}},
+		{
+			name:     "signalhound token wins",
+			env:      map[string]string{"GITHUB_TOKEN": "default", "SIGNALHOUND_GITHUB_TOKEN": "signalhound"},
+			expected: []string{"signalhound"},
+		},
+		{
+			name:     "comma separated tokens",
+			env:      map[string]string{"SIGNALHOUND_GITHUB_TOKENS": "first, second,,", "SIGNALHOUND_GITHUB_TOKEN": "single"},
+			expected: []string{"first", "second"},
+		},
+		{
+			name:     "flags win",
+			flags:    []string{"flag-first", "flag-second"},
+			env:      map[string]string{"SIGNALHOUND_GITHUB_TOKENS": "first,second"},
+			expected: []string{"flag-first", "flag-second"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{"SIGNALHOUND_GITHUB_TOKENS", "SIGNALHOUND_GITHUB_TOKEN", "GITHUB_TOKEN"} {
+				t.Setenv(name, tt.env[name])
+			}
+			assert.Equal(t, tt.expected, githubTokens(tt.flags))
+		})
+	}
+}
@@ -0,0 +1,113 @@
+/* Copyright 2025 Amim Knabben */
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/signalhound/internal/testgrid"
+)
+
+// ackOptions holds the flags of the ack command
+type ackOptions struct {
+	ackFile string
+	expires string
+	reason  string
+	remove  bool
+}
+
+func init() {
+	rootCmd.AddCommand(newAckCmd(&ackOptions{}))
+}
+
+// newAckCmd returns the ack command binding its flags to the options.
+func newAckCmd(o *ackOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ack [test key...]",
+		Short: "Acknowledge known-broken tests, muting them from the reports and the TUI",
+		Long: "Add the tests, given by their key <dashboard>/<tab>/<test name>, to the ack file read with " +
+			"abstract --ack-file, or remove them with --remove. Without test key the acknowledged tests are listed.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run(cmd.OutOrStdout(), args, time.Now())
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVar(&o.ackFile, "ack-file", "",
+		"JSON file of the acknowledged tests, created when missing.")
+	flags.StringVar(&o.expires, "expires", "",
+		"last day the tests are acknowledged, like 2025-10-01, they never expire by default.")
+	flags.StringVar(&o.reason, "reason", "",
+		"why the tests are acknowledged, e.g. the issue tracking them.")
+	flags.BoolVar(&o.remove, "remove", false,
+		"remove the tests from the ack file instead of acknowledging them.")
+	return cmd
+}
+
+// run updates the ack file with the test keys, or lists it without keys.
+func (o *ackOptions) run(w io.Writer, keys []string, now time.Time) error {
+	if strings.TrimSpace(o.ackFile) == "" {
+		return errors.New("--ack-file is required")
+	}
+	entries, err := testgrid.ReadAckFile(o.ackFile)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if len(keys) == 0 {
+		return renderAcks(w, entries, now)
+	}
+
+	added := testgrid.AckEntry{Expires: o.expires, Reason: o.reason}
+	if _, err := testgrid.ParseAckExpiry(o.expires); err != nil {
+		return fmt.Errorf("invalid --expires: %w", err)
+	}
+	byKey := make(map[string]testgrid.AckEntry, len(entries))
+	for _, entry := range entries {
+		byKey[testgrid.AckKey(entry.Key)] = entry
+	}
+	for _, key := range keys {
+		key = testgrid.AckKey(key)
+		if strings.Count(key, "/") < 2 {
+			return fmt.Errorf("invalid test key %q, expected <dashboard>/<tab>/<test name>", key)
+		}
+		if o.remove {
+			delete(byKey, key)
+			continue
+		}
+		added.Key = key
+		byKey[key] = added
+	}
+	entries = make([]testgrid.AckEntry, 0, len(byKey))
+	for _, entry := range byKey {
+		entries = append(entries, entry)
+	}
+	return testgrid.WriteAckFile(o.ackFile, entries)
+}
+
+// renderAcks lists the acknowledged tests with their expiry and reason.
+func renderAcks(w io.Writer, entries []testgrid.AckEntry, now time.Time) error {
+	acks, err := testgrid.NewAcknowledgments(entries)
+	if err != nil {
+		return err
+	}
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "TEST\tEXPIRES\tREASON")
+	for _, entry := range entries {
+		expires := entry.Expires
+		switch {
+		case expires == "":
+			expires = "never"
+		case !acks.Acknowledged(testgrid.AckKey(entry.Key), now):
+			expires += " (expired)"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", entry.Key, expires, entry.Reason)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"sigs.k8s.io/signalhound/internal/testgrid"
+)
+
+func TestRunAck(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "acks.json")
+	now := time.Date(2025, 10, 2, 0, 0, 0, 0, time.UTC)
+	var buf bytes.Buffer
+
+	o := &ackOptions{ackFile: path, reason: "kubernetes/kubernetes#1"}
+	assert.NoError(t, o.run(&buf, []string{"sig-release-master-blocking/build-master/Overall"}, now))
+	o = &ackOptions{ackFile: path, expires: "2025-10-01"}
+	assert.NoError(t, o.run(&buf, []string{"sig-release-master-blocking/build-master/kubetest.Up"}, now))
+	entries, err := testgrid.ReadAckFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []testgrid.AckEntry{
+		{Key: "sig-release-master-blocking/build-master/kubetest.up", Expires: "2025-10-01"},
+		{Key: "sig-release-master-blocking/build-master/overall", Reason: "kubernetes/kubernetes#1"},
+	}, entries)
+
+	assert.NoError(t, (&ackOptions{ackFile: path}).run(&buf, nil, now))
+	assert.Equal(t, "TEST                                                  EXPIRES               REASON\n"+
+		"sig-release-master-blocking/build-master/kubetest.up  2025-10-01 (expired)  \n"+
+		"sig-release-master-blocking/build-master/overall      never                 kubernetes/kubernetes#1\n", buf.String())
+
+	o = &ackOptions{ackFile: path, remove: true}
+	assert.NoError(t, o.run(&buf, []string{"sig-release-master-blocking/build-master/kubetest.up"}, now))
+	entries, err = testgrid.ReadAckFile(path)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestRunAckErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "acks.json")
+	tests := []struct {
+		name    string
+		o       ackOptions
+		keys    []string
+		wantErr string
+	}{
+		{name: "missing ack file", keys: []string{"a/b/c"}, wantErr: "--ack-file is required"},
+		{name: "invalid expiry", o: ackOptions{ackFile: path, expires: "tomorrow"}, keys: []string{"a/b/c"}, wantErr: "invalid --expires"},
+		{name: "invalid key", o: ackOptions{ackFile: path}, keys: []string{"build-master"}, wantErr: "invalid test key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorContains(t, tt.o.run(&bytes.Buffer{}, tt.keys, time.Now()), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,163 @@
+/* Copyright 2025 Amim Knabben */
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"sigs.k8s.io/signalhound/internal/github"
+	"sigs.k8s.io/signalhound/internal/output"
+	"sigs.k8s.io/signalhound/internal/tui"
+	"sigs.k8s.io/signalhound/internal/version"
+)
+
+// fieldsFormats are the formats of the fields command
+var fieldsFormats = []string{output.FormatTable, output.FormatJSON}
+
+// fieldsOptions holds the flags of the fields command
+type fieldsOptions struct {
+	projectID    string
+	tokens       []string
+	outputFormat string
+	caCert       string
+	userAgent    string
+	noTUI        bool
+}
+
+func init() {
+	rootCmd.AddCommand(newFieldsCmd(&fieldsOptions{}))
+}
+
+// newFieldsCmd returns the fields command binding its flags to the options.
+func newFieldsCmd(o *fieldsOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fields",
+		Short: "List the fields of the GitHub project with their type and options",
+		Long: "List the fields of the GitHub project with their type, ID and options, " +
+			"to find the names used by --field-name and by the field updates of draft issues. " +
+			"On a terminal the fields are browsed interactively and the ID of the picked field or option is printed.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run(cmd)
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVar(&o.projectID, "project-id", "",
+		"GitHub project listed, either the node ID (PVT_...) or the project number.")
+	flags.StringArrayVar(&o.tokens, "token", nil,
+		"GitHub token used to read the project. Prefer SIGNALHOUND_GITHUB_TOKEN, flags are visible in the process list.")
+	flags.StringVar(&o.caCert, "ca-cert", "",
+		"PEM bundle of certificate authorities trusted by the GitHub client, in addition to the system ones.")
+	flags.StringVar(&o.userAgent, "user-agent", version.UserAgent(),
+		"User-Agent header of the GitHub requests.")
+	flags.StringVarP(&o.outputFormat, "output", "o", output.FormatTable,
+		"format of the fields, one of: "+strings.Join(fieldsFormats, ", ")+".")
+	flags.BoolVar(&o.noTUI, "no-tui", false,
+		"print the fields instead of browsing them interactively on a terminal.")
+	return cmd
+}
+
+// run lists the project fields.
+func (o *fieldsOptions) run(cmd *cobra.Command) error {
+	if !slices.Contains(fieldsFormats, o.outputFormat) {
+		return fmt.Errorf("unknown output format %q, valid values are: %s", o.outputFormat, strings.Join(fieldsFormats, ", "))
+	}
+	if strings.TrimSpace(o.projectID) == "" {
+		return errors.New("--project-id is required")
+	}
+	tokens := githubTokens(o.tokens)
+	if len(tokens) == 0 {
+		return fmt.Errorf("%w to list the project fields", github.ErrTokenMissing)
+	}
+
+	client, err := githubHTTPClient(defaultGitHubTimeout*time.Second, o.caCert, o.userAgent)
+	if err != nil {
+		return err
+	}
+	gh, err := github.NewProjectManager(cmd.Context(), tokens[0], github.Options{
+		ProjectID:          o.projectID,
+		HTTPClient:         client,
+		EmptyFieldsRetries: defaultFieldsRetries,
+		Timeout:            defaultGitHubTimeout * time.Second,
+	})
+	if err != nil {
+		return err
+	}
+	fields, err := gh.GetProjectFields()
+	if err != nil {
+		return err
+	}
+	if o.useTUI(cmd.OutOrStdout()) {
+		id, err := tui.PickField(fields)
+		if err != nil || id == "" {
+			return err
+		}
+		_, err = fmt.Fprintln(cmd.OutOrStdout(), id)
+		return err
+	}
+	return renderFields(cmd.OutOrStdout(), fields, o.outputFormat)
+}
+
+// useTUI reports whether the fields are browsed interactively, only for the
+// table format written to a terminal unless --no-tui is set.
+func (o *fieldsOptions) useTUI(w io.Writer) bool {
+	if o.noTUI || o.outputFormat != output.FormatTable {
+		return false
+	}
+	file, ok := w.(*os.File)
+	return ok && term.IsTerminal(int(file.Fd()))
+}
+
+// fieldOption is a single select option of a listed field
+type fieldOption struct {
+	Name string `json:"name"`
+	ID   string `json:"id"`
+}
+
+// fieldRow is a listed project field
+type fieldRow struct {
+	Name    string        `json:"name"`
+	Type    string        `json:"type"`
+	ID      string        `json:"id"`
+	Options []fieldOption `json:"options,omitempty"`
+}
+
+// renderFields writes the project fields in the format, the options of each
+// field sorted by name.
+func renderFields(w io.Writer, fields []github.ProjectFieldInfo, format string) error {
+	rows := make([]fieldRow, 0, len(fields))
+	for _, field := range fields {
+		row := fieldRow{Name: string(field.Name), Type: string(field.DataType), ID: fmt.Sprintf("%v", field.ID)}
+		for name, id := range field.Options {
+			row.Options = append(row.Options, fieldOption{Name: name, ID: fmt.Sprintf("%v", id)})
+		}
+		sort.Slice(row.Options, func(i, j int) bool { return row.Options[i].Name < row.Options[j].Name })
+		rows = append(rows, row)
+	}
+
+	if format == output.FormatJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(rows)
+	}
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "FIELD\tTYPE\tID")
+	for _, row := range rows {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", row.Name, row.Type, row.ID)
+		for _, option := range row.Options {
+			fmt.Fprintf(tw, "  %s\t\t%s\n", option.Name, option.ID)
+		}
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	g4 "github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+
+	"sigs.k8s.io/signalhound/internal/github"
+	"sigs.k8s.io/signalhound/internal/output"
+)
+
+func sampleFields() []github.ProjectFieldInfo {
+	return []github.ProjectFieldInfo{
+		{ID: "status-field", Name: "Status", DataType: g4.ProjectV2FieldTypeSingleSelect, Options: map[string]interface{}{
+			"Triage": "triage-id", "Draft": "draft-id",
+		}},
+		{ID: "failures-field", Name: "Failure Count", DataType: g4.ProjectV2FieldTypeNumber, Options: map[string]interface{}{}},
+	}
+}
+
+func TestRenderFields(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, renderFields(&buf, sampleFields(), output.FormatTable))
+	assert.Equal(t, "FIELD          TYPE           ID\n"+
+		"Status         SINGLE_SELECT  status-field\n"+
+		"  Draft                       draft-id\n"+
+		"  Triage                      triage-id\n"+
+		"Failure Count  NUMBER         failures-field\n", buf.String())
+
+	buf.Reset()
+	assert.NoError(t, renderFields(&buf, sampleFields(), output.FormatJSON))
+	assert.JSONEq(t, `[
+		{"name":"Status","type":"SINGLE_SELECT","id":"status-field","options":[{"name":"Draft","id":"draft-id"},{"name":"Triage","id":"triage-id"}]},
+		{"name":"Failure Count","type":"NUMBER","id":"failures-field"}
+	]`, buf.String())
+}
+
+func TestFieldsUseTUI(t *testing.T) {
+	// a buffer is not a terminal
+	assert.False(t, (&fieldsOptions{outputFormat: output.FormatTable}).useTUI(&bytes.Buffer{}))
+
+	devNull, err := os.Open(os.DevNull)
+	assert.NoError(t, err)
+	defer devNull.Close() // nolint
+	assert.False(t, (&fieldsOptions{outputFormat: output.FormatTable}).useTUI(devNull))
+	assert.False(t, (&fieldsOptions{outputFormat: output.FormatJSON}).useTUI(devNull))
+	assert.False(t, (&fieldsOptions{outputFormat: output.FormatTable, noTUI: true}).useTUI(devNull))
+}
+
+func TestRunFieldsFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "missing project", wantErr: "--project-id is required"},
+		{name: "missing token", args: []string{"--project-id", "PVT_kwDOAM_34M4AAThW"}, wantErr: github.ErrTokenMissing.Error()},
+		{name: "unknown format", args: []string{"--project-id", "PVT_kwDOAM_34M4AAThW", "-o", "markdown"}, wantErr: "unknown output format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("SIGNALHOUND_GITHUB_TOKENS", "")
+			t.Setenv("SIGNALHOUND_GITHUB_TOKEN", "")
+			t.Setenv("GITHUB_TOKEN", "")
+			cmd := newFieldsCmd(&fieldsOptions{})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+			cmd.SetArgs(tt.args)
+			assert.ErrorContains(t, cmd.Execute(), tt.wantErr)
+		})
+	}
+}
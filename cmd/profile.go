@@ -0,0 +1,75 @@
+/* Copyright 2025 Amim Knabben */
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"strings"
+)
+
+const (
+	profileCPU = "cpu"
+	profileMem = "mem"
+)
+
+// profileModes are the values of the --profile flag
+var profileModes = []string{profileCPU, profileMem}
+
+// profiler writes the pprof profile of a run to its file
+type profiler struct {
+	mode string
+	file *os.File
+}
+
+// startProfile starts profiling the run in the mode, writing to the out file,
+// signalhound.<mode>.pprof when empty. No profiler is returned for an empty
+// mode.
+func startProfile(mode, out string) (*profiler, error) {
+	if mode == "" {
+		return nil, nil
+	}
+	if mode != profileCPU && mode != profileMem {
+		return nil, fmt.Errorf("invalid --profile %q, expected one of: %s", mode, strings.Join(profileModes, ", "))
+	}
+	if out == "" {
+		out = "signalhound." + mode + ".pprof"
+	}
+	file, err := os.Create(out)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the profile: %w", err)
+	}
+	if mode == profileCPU {
+		if err := pprof.StartCPUProfile(file); err != nil {
+			file.Close() // nolint:errcheck
+			return nil, fmt.Errorf("failed to start the CPU profile: %w", err)
+		}
+	}
+	return &profiler{mode: mode, file: file}, nil
+}
+
+// stop writes the profile, the heap after a garbage collection for the mem
+// mode, and closes its file. It is a no-op on a nil profiler.
+func (p *profiler) stop() error {
+	if p == nil {
+		return nil
+	}
+	var err error
+	switch p.mode {
+	case profileCPU:
+		pprof.StopCPUProfile()
+	case profileMem:
+		// collect first, so the profile shows the live heap at exit
+		runtime.GC()
+		err = pprof.WriteHeapProfile(p.file)
+	}
+	if closeErr := p.file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write the profile %s: %w", p.file.Name(), err)
+	}
+	return nil
+}
@@ -1,9 +1,18 @@
 package cmd
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"golang.org/x/term"
+
+	"sigs.k8s.io/signalhound/internal/output"
 )
 
 var (
@@ -11,11 +20,114 @@ var (
 		Use:   "signalhound",
 		Short: "signalhound search for issues and flaky tests on Kubernetes",
 		Long:  "signalhound search for issues and flaky tests on Kubernetes",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := bindEnv(cmd.Flags()); err != nil {
+				return err
+			}
+			if err := startMaxRuntime(cmd, maxRuntime); err != nil {
+				return err
+			}
+			var err error
+			runProfiler, err = startProfile(profileMode, profileOut)
+			return err
+		},
 	}
+	colorMode string
+
+	// profileMode and profileOut are the hidden diagnostic flags profiling the run
+	profileMode string
+	profileOut  string
+	runProfiler *profiler
+
+	// maxRuntime is the deadline in seconds of the whole run, canceling the
+	// context of the command when exceeded
+	maxRuntime int
+	runCtx     context.Context
+	runCancel  context.CancelFunc = func() {}
 )
 
+// exitMaxRuntime is the exit code of a run canceled by --max-runtime, the one
+// of timeout(1), so schedulers can tell it from a failed run.
+const exitMaxRuntime = 124
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&colorMode, "color", output.ColorAuto,
+		"use colors in the TUI and table output, one of: "+strings.Join(output.Colors, ", ")+". auto disables colors when stdout is not a terminal or NO_COLOR is set.")
+	rootCmd.PersistentFlags().StringVar(&profileMode, "profile", "",
+		"diagnostic aid, profile the run with pprof, one of: "+strings.Join(profileModes, ", ")+".")
+	rootCmd.PersistentFlags().StringVar(&profileOut, "profile-out", "",
+		"file of the --profile profile, written on exit. Defaults to signalhound.<mode>.pprof.")
+	rootCmd.PersistentFlags().IntVar(&maxRuntime, "max-runtime", 0,
+		"deadline in seconds of the whole run, including the retries and every TestGrid and GitHub request, exiting with code 124 when exceeded, to disable use 0.")
+	_ = rootCmd.PersistentFlags().MarkHidden("profile")
+	_ = rootCmd.PersistentFlags().MarkHidden("profile-out")
+}
+
+// envPrefix prefixes the environment variables setting the flags.
+const envPrefix = "SIGNALHOUND_"
+
+// envName returns the environment variable of a flag, e.g. SIGNALHOUND_MIN_FAILURE
+// for --min-failure.
+func envName(flag string) string {
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(flag, "-", "_"))
+}
+
+// bindEnv sets the flags not given on the command line from their environment
+// variable, so an explicit flag wins over the environment, which wins over the
+// default.
+func bindEnv(flags *pflag.FlagSet) error {
+	var err error
+	flags.VisitAll(func(flag *pflag.Flag) {
+		if err != nil || flag.Changed {
+			return
+		}
+		value, ok := os.LookupEnv(envName(flag.Name))
+		if !ok {
+			return
+		}
+		if setErr := flags.Set(flag.Name, value); setErr != nil {
+			err = fmt.Errorf("invalid %s: %w", envName(flag.Name), setErr)
+		}
+	})
+	return err
+}
+
+// colorEnabled resolves the --color flag for the current stdout.
+func colorEnabled() (bool, error) {
+	return output.ResolveColor(colorMode, term.IsTerminal(int(os.Stdout.Fd())))
+}
+
+// startMaxRuntime sets the deadline of the run, in seconds, on the context of
+// the command, none for 0 seconds.
+func startMaxRuntime(cmd *cobra.Command, seconds int) error {
+	if seconds < 0 {
+		return fmt.Errorf("--max-runtime must not be negative, got %d", seconds)
+	}
+	if seconds > 0 {
+		runCtx, runCancel = context.WithTimeout(cmd.Context(), time.Duration(seconds)*time.Second)
+		cmd.SetContext(runCtx)
+	}
+	return nil
+}
+
+// runtimeExceeded reports whether the run was canceled by --max-runtime.
+func runtimeExceeded() bool {
+	return runCtx != nil && errors.Is(runCtx.Err(), context.DeadlineExceeded)
+}
+
 func Execute() {
 	err := rootCmd.Execute()
+	exceeded := runtimeExceeded()
+	runCancel()
+	// the profile is written even when the run failed
+	if stopErr := runProfiler.stop(); stopErr != nil {
+		fmt.Fprintln(os.Stderr, stopErr)
+	}
+	// the partial output is already written, the run still fails
+	if exceeded {
+		fmt.Fprintf(os.Stderr, "Error: --max-runtime of %ds exceeded, the run was canceled\n", maxRuntime)
+		os.Exit(exitMaxRuntime)
+	}
 	if err != nil {
 		os.Exit(1)
 	}
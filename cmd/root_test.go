@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvName(t *testing.T) {
+	assert.Equal(t, "SIGNALHOUND_MIN_FAILURE", envName("min-failure"))
+	assert.Equal(t, "SIGNALHOUND_OUTPUT", envName("output"))
+}
+
+func TestBindEnv(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		env      map[string]string
+		expected int
+		wantErr  bool
+	}{
+		{name: "default", expected: 1},
+		{name: "environment over default", env: map[string]string{"SIGNALHOUND_MIN_FAILURE": "3"}, expected: 3},
+		{name: "flag over environment", args: []string{"--min-failure", "5"}, env: map[string]string{"SIGNALHOUND_MIN_FAILURE": "3"}, expected: 5},
+		{name: "invalid environment", env: map[string]string{"SIGNALHOUND_MIN_FAILURE": "many"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+			flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			minFailure := flags.Int("min-failure", 1, "")
+			assert.NoError(t, flags.Parse(tt.args))
+
+			err := bindEnv(flags)
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "SIGNALHOUND_MIN_FAILURE")
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, *minFailure)
+		})
+	}
+}
+
+func TestProfile(t *testing.T) {
+	p, err := startProfile("", "")
+	assert.NoError(t, err)
+	assert.Nil(t, p)
+	assert.NoError(t, p.stop())
+
+	_, err = startProfile("block", "")
+	assert.ErrorContains(t, err, "invalid --profile")
+
+	for _, mode := range profileModes {
+		t.Run(mode, func(t *testing.T) {
+			out := filepath.Join(t.TempDir(), mode+".pprof")
+			p, err := startProfile(mode, out)
+			assert.NoError(t, err)
+			assert.NoError(t, p.stop())
+			info, err := os.Stat(out)
+			assert.NoError(t, err)
+			assert.NotZero(t, info.Size())
+		})
+	}
+}
+
+func TestMaxRuntime(t *testing.T) {
+	defer func() { runCtx, runCancel = nil, func() {} }()
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	assert.ErrorContains(t, startMaxRuntime(cmd, -1), "--max-runtime must not be negative")
+	assert.NoError(t, startMaxRuntime(cmd, 0))
+	_, ok := cmd.Context().Deadline()
+	assert.False(t, ok)
+	assert.False(t, runtimeExceeded())
+
+	assert.NoError(t, startMaxRuntime(cmd, 60))
+	deadline, ok := cmd.Context().Deadline()
+	assert.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, time.Second)
+	assert.False(t, runtimeExceeded())
+
+	// a canceled run did not exceed its deadline
+	runCancel()
+	assert.False(t, runtimeExceeded())
+}
@@ -0,0 +1,88 @@
+/* Copyright 2025 Amim Knabben */
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/signalhound/internal/snapshot"
+)
+
+// defaultKeepSnapshots is the number of snapshots kept in the directory.
+const defaultKeepSnapshots = 30
+
+// snapshotOptions holds the flags of the snapshot command, scraping with the
+// flags of the abstract command
+type snapshotOptions struct {
+	*abstractOptions
+	keep int
+}
+
+// newSnapshotCmd returns the snapshot subcommand of abstract binding its flags
+// to the options.
+func newSnapshotCmd(o *snapshotOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Save the current results to a timestamped JSON file for later comparisons",
+		Long: "Scrape TestGrid with the abstract flags and save the failing and flaky tests to a JSON " +
+			"file of the snapshot directory named after the time of the scrape, pruning the oldest snapshots beyond --keep.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run(cmd)
+		},
+	}
+	flags := cmd.Flags()
+	flags.IntVar(&o.keep, "keep", defaultKeepSnapshots,
+		"number of most recent snapshots kept in --snapshot-dir, the older ones are removed, to disable use 0.")
+	return cmd
+}
+
+// run scrapes TestGrid and saves the snapshot.
+func (o *snapshotOptions) run(cmd *cobra.Command) error {
+	if strings.TrimSpace(o.snapshotDir) == "" {
+		return errors.New("--snapshot-dir is required")
+	}
+	if o.keep < 0 {
+		return fmt.Errorf("--keep must not be negative, got %d", o.keep)
+	}
+	if err := o.validate(); err != nil {
+		return err
+	}
+	tg, err := o.testGridClient(cmd.Context())
+	if err != nil {
+		return err
+	}
+	dashboards, err := o.resolveDashboards(tg)
+	if err != nil {
+		return err
+	}
+
+	// like the report, a partial snapshot is saved when some dashboards are unavailable
+	tabs, fetchErr := FetchTabSummary(tg, dashboards, o.filterOptions(), o.retryDashboards)
+	if fetchErr != nil && len(tabs) == 0 {
+		return fetchErr
+	}
+	if fetchErr != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "warning: partial results, some sources were unavailable:\n%v\n", fetchErr)
+	}
+	path, err := snapshot.Write(o.snapshotDir, snapshot.Snapshot{
+		Time:       time.Now().UTC(),
+		Dashboards: scrapedDashboards(dashboards, fetchErr),
+		Tabs:       tabs,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), path)
+
+	pruned, err := snapshot.Prune(o.snapshotDir, o.keep)
+	for _, path := range pruned {
+		slog.Debug("snapshot pruned", "path", path)
+	}
+	return err
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"sigs.k8s.io/signalhound/api/v1alpha1"
+	"sigs.k8s.io/signalhound/internal/snapshot"
+	"sigs.k8s.io/signalhound/internal/testgrid"
+)
+
+// runSnapshot runs the snapshot subcommand of abstract against the fake.
+func runSnapshot(t *testing.T, fake *fakeTestGrid, args ...string) (stdout string, err error) {
+	t.Helper()
+	cmd := newAbstractCmd(&abstractOptions{
+		newTestGridClient: func(context.Context, *http.Client, int, time.Duration, time.Duration) testgrid.TestGridClient {
+			return fake
+		},
+	})
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(append([]string{"snapshot"}, args...))
+	err = cmd.Execute()
+	return out.String(), err
+}
+
+func TestRunSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	// older snapshots, the first one is pruned
+	start := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	for day := 0; day < 2; day++ {
+		_, err := snapshot.Write(dir, snapshot.Snapshot{Time: start.AddDate(0, 0, day)})
+		assert.NoError(t, err)
+	}
+	tabs := []*v1alpha1.DashboardTab{{
+		DashboardName: "sig-release-master-blocking",
+		TabName:       "build-master",
+		TestRuns:      []v1alpha1.TestResult{{TestName: "Overall", FailureCount: 2}},
+	}}
+	stdout, err := runSnapshot(t, &fakeTestGrid{tabs: tabs}, "--snapshot-dir", dir, "--keep", "2", "--dashboard", "sig-release-master-blocking")
+	assert.NoError(t, err)
+
+	paths, err := snapshot.List(dir)
+	assert.NoError(t, err)
+	assert.Len(t, paths, 2)
+	assert.Equal(t, filepath.Join(dir, "20251002T000000Z.json"), paths[0])
+	assert.Equal(t, paths[1], strings.TrimSpace(stdout))
+
+	saved, err := snapshot.Read(paths[1])
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sig-release-master-blocking"}, saved.Dashboards)
+	assert.Equal(t, "Overall", saved.Tabs[0].TestRuns[0].TestName)
+}
+
+func TestRunSnapshotErrors(t *testing.T) {
+	_, err := runSnapshot(t, &fakeTestGrid{})
+	assert.ErrorContains(t, err, "--snapshot-dir is required")
+	_, err = runSnapshot(t, &fakeTestGrid{}, "--snapshot-dir", t.TempDir(), "--keep", "-1")
+	assert.ErrorContains(t, err, "--keep must not be negative")
+	_, err = runSnapshot(t, &fakeTestGrid{}, "--snapshot-dir", t.TempDir(), "--concurrency", "0")
+	assert.ErrorContains(t, err, "--concurrency must be at least 1")
+}
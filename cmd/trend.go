@@ -0,0 +1,98 @@
+/* Copyright 2025 Amim Knabben */
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/signalhound/internal/output"
+	"sigs.k8s.io/signalhound/internal/snapshot"
+)
+
+// trendFormats are the formats of the trend command
+var trendFormats = []string{output.FormatTable, output.FormatJSON}
+
+// trendOptions holds the flags of the trend command
+type trendOptions struct {
+	dir          string
+	last         int
+	outputFormat string
+}
+
+func init() {
+	rootCmd.AddCommand(newTrendCmd(&trendOptions{}))
+}
+
+// newTrendCmd returns the trend command binding its flags to the options.
+func newTrendCmd(o *trendOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "trend",
+		Short: "Report how the failures and flakes of each test evolved over the snapshots",
+		Long: "Compare the snapshots saved by abstract snapshot, the oldest with the most recent, and report per test " +
+			"whether it is worsening, new, stable, improving or recovered with an ASCII trend of its failures and flakes.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run(cmd.OutOrStdout())
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVar(&o.dir, "snapshot-dir", "",
+		"directory of the snapshots written by abstract snapshot.")
+	flags.IntVar(&o.last, "last", 0,
+		"compare only this number of most recent snapshots, to disable use 0.")
+	flags.StringVarP(&o.outputFormat, "output", "o", output.FormatTable,
+		"format of the trends, one of: "+strings.Join(trendFormats, ", ")+".")
+	return cmd
+}
+
+// run reports the trends of the snapshots.
+func (o *trendOptions) run(w io.Writer) error {
+	if !slices.Contains(trendFormats, o.outputFormat) {
+		return fmt.Errorf("unknown output format %q, valid values are: %s", o.outputFormat, strings.Join(trendFormats, ", "))
+	}
+	if strings.TrimSpace(o.dir) == "" {
+		return errors.New("--snapshot-dir is required")
+	}
+	if o.last < 0 {
+		return fmt.Errorf("--last must not be negative, got %d", o.last)
+	}
+	snapshots, err := snapshot.Load(o.dir)
+	if err != nil {
+		return err
+	}
+	if o.last > 0 && len(snapshots) > o.last {
+		snapshots = snapshots[len(snapshots)-o.last:]
+	}
+	if len(snapshots) < 2 {
+		return fmt.Errorf("at least 2 snapshots are needed in %s, found %d", o.dir, len(snapshots))
+	}
+	return renderTrends(w, snapshots, snapshot.Trends(snapshots), o.outputFormat)
+}
+
+// renderTrends writes the trends in the format, the table counting the
+// failures and flakes of the oldest and the most recent snapshots.
+func renderTrends(w io.Writer, snapshots []*snapshot.Snapshot, trends []snapshot.Trend, format string) error {
+	if format == output.FormatJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(trends)
+	}
+	const layout = "2006-01-02 15:04"
+	fmt.Fprintf(w, "%d snapshots from %s to %s UTC\n\n", len(snapshots),
+		snapshots[0].Time.UTC().Format(layout), snapshots[len(snapshots)-1].Time.UTC().Format(layout))
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "DIRECTION\tTREND\tFAILURES\tFLAKES\tTEST")
+	for _, trend := range trends {
+		first, last := trend.Points[0], trend.Points[len(trend.Points)-1]
+		fmt.Fprintf(tw, "%s\t%s\t%d -> %d\t%d -> %d\t%s\n", trend.Direction, trend.ASCII(),
+			first.Failures, last.Failures, first.Flakes, last.Flakes, trend.Key)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"sigs.k8s.io/signalhound/api/v1alpha1"
+	"sigs.k8s.io/signalhound/internal/output"
+	"sigs.k8s.io/signalhound/internal/snapshot"
+)
+
+// writeSnapshots writes a snapshot a day of the build-master tab with the
+// failures of the Overall test
+func writeSnapshots(t *testing.T, failures ...int) string {
+	t.Helper()
+	dir := t.TempDir()
+	for day, count := range failures {
+		_, err := snapshot.Write(dir, snapshot.Snapshot{
+			Time: time.Date(2025, 10, day+1, 12, 0, 0, 0, time.UTC),
+			Tabs: []*v1alpha1.DashboardTab{{
+				DashboardName: "sig-release-master-blocking",
+				TabName:       "build-master",
+				TestRuns:      []v1alpha1.TestResult{{TestName: "Overall", FailureCount: count}},
+			}},
+		})
+		assert.NoError(t, err)
+	}
+	return dir
+}
+
+func TestRunTrend(t *testing.T) {
+	dir := writeSnapshots(t, 4, 1, 2, 3)
+
+	var buf bytes.Buffer
+	o := &trendOptions{dir: dir, outputFormat: output.FormatTable}
+	assert.NoError(t, o.run(&buf))
+	assert.Equal(t, "4 snapshots from 2025-10-01 12:00 to 2025-10-04 12:00 UTC\n\n"+
+		"DIRECTION  TREND  FAILURES  FLAKES  TEST\n"+
+		"improving  #_-=   4 -> 3    0 -> 0  sig-release-master-blocking/build-master/overall\n", buf.String())
+
+	buf.Reset()
+	o = &trendOptions{dir: dir, last: 3, outputFormat: output.FormatJSON}
+	assert.NoError(t, o.run(&buf))
+	var trends []snapshot.Trend
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &trends))
+	assert.Len(t, trends, 1)
+	assert.Equal(t, snapshot.DirectionWorsening, trends[0].Direction)
+	assert.Len(t, trends[0].Points, 3)
+}
+
+func TestRunTrendErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		o       *trendOptions
+		wantErr string
+	}{
+		{name: "format", o: &trendOptions{dir: t.TempDir(), outputFormat: "markdown"}, wantErr: "unknown output format"},
+		{name: "no directory", o: &trendOptions{outputFormat: output.FormatTable}, wantErr: "--snapshot-dir is required"},
+		{name: "negative last", o: &trendOptions{dir: t.TempDir(), last: -1, outputFormat: output.FormatTable}, wantErr: "--last must not be negative"},
+		{name: "single snapshot", o: &trendOptions{dir: writeSnapshots(t, 1), outputFormat: output.FormatTable}, wantErr: "at least 2 snapshots"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorContains(t, tt.o.run(&bytes.Buffer{}), tt.wantErr)
+		})
+	}
+}
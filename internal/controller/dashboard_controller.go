@@ -207,7 +207,8 @@ func (r *DashboardReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			tabName := dashSummary.DashboardTab.TabName
 
 			var tab *testgridv1alpha1.DashboardTab
-			if tab, err = grid.FetchTabTests(&dashSummary, dashboard.Spec.MinFlakes, dashboard.Spec.MinFailures); err != nil {
+			filter := testgrid.FilterOptions{MinFailure: dashboard.Spec.MinFailures, MinFlake: dashboard.Spec.MinFlakes}
+			if tab, err = grid.FetchTabTests(&dashSummary, filter); err != nil {
 				r.log.Error(err, "error fetching table", "tab", tabName)
 				span.RecordError(err)
 				continue
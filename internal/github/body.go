@@ -0,0 +1,85 @@
+package github
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultMaxBodyLength is the maximum number of characters of a draft issue
+// body, under the 65536 characters accepted by GitHub.
+const DefaultMaxBodyLength = 60000
+
+// truncatedMarker ends a body cut to the maximum length.
+const truncatedMarker = "\n\n…truncated"
+
+// codeFence opens and closes a code block of a body.
+const codeFence = "```"
+
+// codeBlockPattern matches a fenced code block of a body, with its content.
+var codeBlockPattern = regexp.MustCompile("(?ms)^```[^\\n]*\\n(.*?)\\n```")
+
+// openFencePattern matches the fences of a body, an odd number of them leaves
+// a code block open.
+var openFencePattern = regexp.MustCompile("(?m)^```")
+
+// truncateBody cuts the body to at most max characters. The longest code
+// block, the error message of the issue templates, is cut first so the rest
+// of the body is kept whole. Otherwise the start of the body is kept, with the
+// header of the issue, an open code block is closed and its fingerprint kept
+// so the issue is still matched to its test. A zero max disables it.
+func truncateBody(body string, max int) string {
+	if max <= 0 || utf8.RuneCountInString(body) <= max {
+		return body
+	}
+	if truncated, ok := truncateCodeBlock(body, max); ok {
+		return truncated
+	}
+	var trailer string
+	if loc := fingerprintPattern.FindStringIndex(body); loc != nil {
+		trailer = "\n\n" + body[loc[0]:loc[1]]
+	}
+	keep := max - utf8.RuneCountInString(truncatedMarker+trailer)
+	if keep <= 0 {
+		// too short for the marker, the body is only cut
+		return string([]rune(body)[:max])
+	}
+	head := cutBody(body, keep)
+	var closing string
+	if len(openFencePattern.FindAllStringIndex(head, -1))%2 == 1 {
+		closing = "\n" + codeFence
+		if keep <= len(closing) {
+			return string([]rune(body)[:max])
+		}
+		head = cutBody(body, keep-len(closing))
+		if len(openFencePattern.FindAllStringIndex(head, -1))%2 == 0 {
+			closing = ""
+		}
+	}
+	return head + closing + truncatedMarker + trailer
+}
+
+// truncateCodeBlock cuts the content of the longest code block of the body so
+// the body fits in max characters, false when the block is too short for it.
+func truncateCodeBlock(body string, max int) (string, bool) {
+	var longest []int
+	for _, loc := range codeBlockPattern.FindAllStringSubmatchIndex(body, -1) {
+		if longest == nil || loc[3]-loc[2] > longest[3]-longest[2] {
+			longest = loc
+		}
+	}
+	if longest == nil {
+		return "", false
+	}
+	content := body[longest[2]:longest[3]]
+	keep := utf8.RuneCountInString(content) - (utf8.RuneCountInString(body) - max) - utf8.RuneCountInString(truncatedMarker)
+	if keep < 0 {
+		return "", false
+	}
+	return body[:longest[2]] + cutBody(content, keep) + truncatedMarker + body[longest[3]:], true
+}
+
+// cutBody returns the first n characters of the body, without trailing spaces.
+func cutBody(body string, n int) string {
+	return strings.TrimRight(string([]rune(body)[:n]), " \t\n")
+}
@@ -0,0 +1,90 @@
+package github
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	g4 "github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTruncateBody(t *testing.T) {
+	fingerprint := Fingerprint("sig-release-master-blocking/build-master/Overall")
+	tests := []struct {
+		name     string
+		body     string
+		max      int
+		expected string
+	}{
+		{name: "disabled", body: "### Which jobs are failing?", expected: "### Which jobs are failing?"},
+		{name: "short enough", body: "### Which jobs are failing?", max: 27, expected: "### Which jobs are failing?"},
+		{name: "truncated", body: "### Header\n\n" + strings.Repeat("▁", 100), max: 30, expected: "### Header\n\n▁▁▁▁▁▁" + truncatedMarker},
+		{name: "fingerprint kept", body: "### Header\n\n" + strings.Repeat("x", 200) + "\n\n" + fingerprint, max: 120,
+			expected: "### Header\n\n" + strings.Repeat("x", 120-12-utf8.RuneCountInString(truncatedMarker+"\n\n"+fingerprint)) + truncatedMarker + "\n\n" + fingerprint},
+		{name: "too short for the marker", body: strings.Repeat("x", 20), max: 5, expected: "xxxxx"},
+		{name: "inside the error block", body: "### Reason\n\n```\n" + strings.Repeat("e", 200) + "\n```\n\n/sig node\n/kind failing-test\n\n" + fingerprint, max: 200,
+			expected: "### Reason\n\n```\n" + strings.Repeat("e", 200-(252+utf8.RuneCountInString(fingerprint)-200)-utf8.RuneCountInString(truncatedMarker)) +
+				truncatedMarker + "\n```\n\n/sig node\n/kind failing-test\n\n" + fingerprint},
+		{name: "open block closed", body: "### Reason\n\n```\n" + strings.Repeat("e", 200), max: 50,
+			expected: "### Reason\n\n```\n" + strings.Repeat("e", 50-16-4-utf8.RuneCountInString(truncatedMarker)) + "\n```" + truncatedMarker},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			truncated := truncateBody(tt.body, tt.max)
+			assert.Equal(t, tt.expected, truncated)
+			if tt.max > 0 {
+				assert.LessOrEqual(t, utf8.RuneCountInString(truncated), tt.max)
+			}
+		})
+	}
+}
+
+func TestCreateDraftIssueOversizedBody(t *testing.T) {
+	const limit = 1000
+	var created string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		if !strings.Contains(string(data), "addProjectV2DraftIssue") {
+			w.Write([]byte(projectFieldsResponse)) // nolint
+			return
+		}
+		var request struct {
+			Variables struct {
+				Input struct {
+					Body string `json:"body"`
+				} `json:"input"`
+			} `json:"variables"`
+		}
+		assert.NoError(t, json.Unmarshal(data, &request))
+		// like GitHub, an oversized body is rejected
+		if utf8.RuneCountInString(request.Variables.Input.Body) > limit {
+			w.Write([]byte(`{"errors":[{"message":"body is too long (maximum is 1000 characters)"}]}`)) // nolint
+			return
+		}
+		created = request.Variables.Input.Body
+		w.Write([]byte(`{"data":{"addProjectV2DraftIssue":{"projectItem":{"id":"PVTI_item","databaseId":1,` + // nolint
+			`"project":{"url":"https://github.com/orgs/kubernetes/projects/68"}}}}}`))
+	}))
+	defer server.Close()
+
+	fingerprint := Fingerprint("sig-release-master-blocking/build-master/Overall")
+	body := "### Which jobs are failing?\n\n" + strings.Repeat("| 2025-10-01 12:00 | failed |\n", 200) + "\n" + fingerprint
+	gh := &ProjectManager{projectID: PROJECT_ID, githubClient: g4.NewEnterpriseClient(server.URL, server.Client())}
+	_, err := gh.CreateDraftIssue("[Failing Test] Overall", body, "master-blocking")
+	assert.ErrorContains(t, err, "body is too long")
+
+	gh.maxBodyLength = limit
+	draft, err := gh.CreateDraftIssue("[Failing Test] Overall", body, "master-blocking")
+	assert.NoError(t, err)
+	assert.Equal(t, "PVTI_item", draft.ItemID)
+	assert.True(t, strings.HasPrefix(created, "### Which jobs are failing?"))
+	assert.Contains(t, created, truncatedMarker)
+	assert.True(t, strings.HasSuffix(created, fingerprint))
+}
@@ -0,0 +1,327 @@
+package github
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	g4 "github.com/shurcooL/githubv4"
+	"sigs.k8s.io/signalhound/internal/version"
+)
+
+// fieldOption is a single select field with the option set on draft issues,
+// the IDs are empty when the project has no such field or option.
+type fieldOption struct {
+	fieldID  g4.ID
+	optionID g4.ID
+}
+
+// fieldUpdate is a field option set on a draft issue, named in the warnings
+type fieldUpdate struct {
+	fieldOption
+	name string
+}
+
+// draftFields are the project fields set on draft issues with their target
+// options, resolved once from the cached project fields.
+type draftFields struct {
+	release fieldOption
+	// releaseOptions are the options of the release field, matched with the
+	// version of the dashboards.
+	releaseOptions map[string]interface{}
+	// releaseText is the K8s Release field when it is a text field, holding
+	// every release of the overlap window.
+	releaseText g4.ID
+	view        fieldOption
+	status      fieldOption
+	// boardFieldID and boardOptions are the Testgrid Board field and its
+	// options, the option set depends on the board of each draft.
+	boardFieldID g4.ID
+	boardOptions map[string]interface{}
+	// boards caches the option matched for each board, guarded by the mutex
+	// of the ProjectManager.
+	boards map[string]g4.ID
+	// firstSeen and lastSeen are the First Seen and Last Seen date fields,
+	// nil when the project has none.
+	firstSeen g4.ID
+	lastSeen  g4.ID
+	// overrides are the options of Options.SetFields, sorted by field name,
+	// and skipped the fields of Options.SkipFields, by field ID.
+	overrides []fieldUpdate
+	skipped   map[g4.ID]bool
+}
+
+// draftFields returns the fields set on draft issues, resolved from the
+// project fields on the first call and cached until the fields are queried
+// again.
+func (g *ProjectManager) draftFields() (*draftFields, error) {
+	fields, err := g.GetProjectFields()
+	if err != nil {
+		return nil, err
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.resolved == nil {
+		resolved := g.resolveDraftFields(fields)
+		if err := g.resolveFieldOverrides(resolved, fields); err != nil {
+			return nil, err
+		}
+		if err := g.validateReleases(fields); err != nil {
+			return nil, err
+		}
+		g.resolved = resolved
+	}
+	return g.resolved, nil
+}
+
+// ValidateFields verifies the fields and options of Options.SetFields and
+// the fields of Options.SkipFields are in the project, and the K8s Release
+// field holds several values when Options.ExtraReleases is set.
+func (g *ProjectManager) ValidateFields() error {
+	_, err := g.draftFields()
+	return err
+}
+
+// resolveDraftFields matches the fields set on draft issues and their options:
+// the configured release or the latest version, the issue tracking view and
+// the draft status.
+func (g *ProjectManager) resolveDraftFields(fields []ProjectFieldInfo) *draftFields {
+	resolved := &draftFields{boards: map[string]g4.ID{}}
+	for _, field := range fields {
+		if g.matchesField(FieldRelease, field) && field.DataType == g4.ProjectV2FieldTypeText {
+			resolved.releaseText = field.ID
+		} else if g.matchesField(FieldRelease, field) {
+			resolved.release = fieldOption{field.ID, releaseOption(field.Options, g.release)}
+			resolved.releaseOptions = field.Options
+		}
+		if g.matchesField(FieldView, field) {
+			resolved.view = fieldOption{field.ID, firstOption(field.Options, "issue-tracking", "issue tracking")}
+		}
+		if g.matchesField(FieldBoard, field) {
+			resolved.boardFieldID = field.ID
+			resolved.boardOptions = field.Options
+		}
+		if g.matchesField(FieldStatus, field) {
+			resolved.status = fieldOption{field.ID, firstOption(field.Options, "drafting", "draft")}
+		}
+		if field.DataType == g4.ProjectV2FieldTypeDate && g.matchesField(FieldFirstSeen, field) {
+			resolved.firstSeen = field.ID
+		}
+		if field.DataType == g4.ProjectV2FieldTypeDate && g.matchesField(FieldLastSeen, field) {
+			resolved.lastSeen = field.ID
+		}
+	}
+	if resolved.firstSeen == nil || resolved.lastSeen == nil {
+		slog.Debug("project without First Seen or Last Seen date field, the missing dates are not set on draft issues",
+			"project", g.projectID, "firstSeen", resolved.firstSeen != nil, "lastSeen", resolved.lastSeen != nil)
+	}
+	return resolved
+}
+
+// resolveFieldOverrides matches the fields and options set and skipped on the
+// draft issues, failing on the names missing from the project.
+func (g *ProjectManager) resolveFieldOverrides(resolved *draftFields, fields []ProjectFieldInfo) error {
+	resolved.skipped = map[g4.ID]bool{}
+	for _, name := range g.skipFields {
+		field, err := lookupField(fields, name)
+		if err != nil {
+			return err
+		}
+		resolved.skipped[field.ID] = true
+	}
+	for name, option := range g.setFields {
+		field, err := lookupField(fields, name)
+		if err != nil {
+			return err
+		}
+		if field.DataType != g4.ProjectV2FieldTypeSingleSelect {
+			return fmt.Errorf("%w: field %q is not a single select field", ErrFieldNotResolved, name)
+		}
+		optionID, err := lookupOption(field, option)
+		if err != nil {
+			return err
+		}
+		resolved.overrides = append(resolved.overrides, fieldUpdate{fieldOption{field.ID, optionID}, string(field.Name)})
+	}
+	sort.Slice(resolved.overrides, func(i, j int) bool {
+		return resolved.overrides[i].name < resolved.overrides[j].name
+	})
+	return nil
+}
+
+// validateReleases verifies the K8s Release field holds several values when
+// extra releases are set: a single select field holds one option, draft
+// issues can only be set to one of the releases.
+func (g *ProjectManager) validateReleases(fields []ProjectFieldInfo) error {
+	if len(g.extraReleases) == 0 {
+		return nil
+	}
+	for _, field := range fields {
+		if !g.matchesField(FieldRelease, field) {
+			continue
+		}
+		if field.DataType != g4.ProjectV2FieldTypeText {
+			return fmt.Errorf("%w: field %q is a %s field holding a single release, got the releases %s",
+				ErrFieldNotResolved, field.Name, strings.ToLower(strings.ReplaceAll(string(field.DataType), "_", " ")), g.releaseText())
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: project has no K8s Release field for the releases %s", ErrFieldNotResolved, g.releaseText())
+}
+
+// releaseText returns the releases of the text K8s Release field separated by
+// commas, e.g. v1.32, v1.31, empty without a configured release.
+func (g *ProjectManager) releaseText() string {
+	var releases []string
+	for _, release := range append([]string{g.release}, g.extraReleases...) {
+		if release != "" {
+			releases = append(releases, "v"+release)
+		}
+	}
+	return strings.Join(releases, ", ")
+}
+
+// lookupField returns the project field of the name ignoring case, or of the ID.
+func lookupField(fields []ProjectFieldInfo, name string) (ProjectFieldInfo, error) {
+	name = strings.TrimSpace(name)
+	names := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if name == fmt.Sprintf("%v", field.ID) || strings.EqualFold(name, strings.TrimSpace(string(field.Name))) {
+			return field, nil
+		}
+		names = append(names, string(field.Name))
+	}
+	sort.Strings(names)
+	return ProjectFieldInfo{}, fmt.Errorf("%w: unknown field %q, valid values are: %s", ErrFieldNotResolved, name, strings.Join(names, ", "))
+}
+
+// lookupOption returns the ID of the option of the field, by name ignoring case.
+func lookupOption(field ProjectFieldInfo, option string) (g4.ID, error) {
+	option = strings.TrimSpace(option)
+	names := optionNames(field.Options)
+	for _, name := range names {
+		if strings.EqualFold(name, option) {
+			return field.Options[name], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: unknown option %q of field %q, valid values are: %s", ErrFieldNotResolved, option, field.Name, strings.Join(names, ", "))
+}
+
+// updates returns the fields set on a draft issue: the release, view, status
+// and board options, unless skipped or overridden, then the other fields set.
+func (d *draftFields) updates(release, board fieldOption) []fieldUpdate {
+	updates := []fieldUpdate{
+		{release, "K8s Release"},
+		{d.view, "View"},
+		{d.status, "Status"},
+		{board, "Testgrid Board"},
+	}
+	overridden := map[g4.ID]bool{}
+	for i := range updates {
+		for _, override := range d.overrides {
+			if updates[i].fieldID != nil && updates[i].fieldID == override.fieldID {
+				updates[i].optionID = override.optionID
+				overridden[override.fieldID] = true
+			}
+		}
+	}
+	for _, override := range d.overrides {
+		if !overridden[override.fieldID] {
+			updates = append(updates, override)
+		}
+	}
+	kept := updates[:0]
+	for _, update := range updates {
+		if !d.skipped[update.fieldID] {
+			kept = append(kept, update)
+		}
+	}
+	return kept
+}
+
+// dateUpdate is a date field set on a draft issue
+type dateUpdate struct {
+	fieldID g4.ID
+	name    string
+	date    time.Time
+}
+
+// dateUpdates returns the First Seen and Last Seen dates set on a draft
+// issue, for the date fields of the project not skipped and the times set.
+func (d *draftFields) dateUpdates(firstSeen, lastSeen time.Time) []dateUpdate {
+	var updates []dateUpdate
+	for _, update := range []dateUpdate{{d.firstSeen, "First Seen", firstSeen}, {d.lastSeen, "Last Seen", lastSeen}} {
+		if update.fieldID == nil || d.skipped[update.fieldID] || update.date.IsZero() {
+			continue
+		}
+		update.date = update.date.UTC().Truncate(24 * time.Hour)
+		updates = append(updates, update)
+	}
+	return updates
+}
+
+// board returns the Testgrid Board field with the option of the board.
+func (g *ProjectManager) board(resolved *draftFields, board string) fieldOption {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	optionID, ok := resolved.boards[board]
+	if !ok {
+		optionID = matchBoardOption(resolved.boardOptions, board)
+		resolved.boards[board] = optionID
+	}
+	return fieldOption{resolved.boardFieldID, optionID}
+}
+
+// releaseField returns the release field with the option of the dashboard
+// version when ReleaseFromDashboard is set and the project has it, otherwise
+// the configured or latest release.
+func (g *ProjectManager) releaseField(resolved *draftFields, dashboard string) fieldOption {
+	if !g.releaseFromDashboard {
+		return resolved.release
+	}
+	release := version.Extract(dashboard)
+	if release == "" {
+		return resolved.release
+	}
+	if optionID := releaseOption(resolved.releaseOptions, release); optionID != nil {
+		return fieldOption{resolved.release.fieldID, optionID}
+	}
+	return resolved.release
+}
+
+// releaseTextOf returns the value of the text K8s Release field of a draft
+// issue: the version of its dashboard when ReleaseFromDashboard is set and the
+// dashboard is versioned, otherwise the configured releases.
+func (g *ProjectManager) releaseTextOf(dashboard string) string {
+	if release := version.Extract(dashboard); g.releaseFromDashboard && release != "" {
+		return "v" + release
+	}
+	return g.releaseText()
+}
+
+// firstOption returns the ID of the first option, by name, containing one of
+// the substrings ignoring case, nil when none does.
+func firstOption(options map[string]interface{}, substrings ...string) g4.ID {
+	for _, name := range optionNames(options) {
+		for _, substring := range substrings {
+			if strings.Contains(strings.ToLower(name), substring) {
+				return options[name]
+			}
+		}
+	}
+	return nil
+}
+
+// optionNames returns the names of the options sorted, so the options are
+// matched in the same order on every run rather than in the random order of
+// the map.
+func optionNames(options map[string]interface{}) []string {
+	names := make([]string, 0, len(options))
+	for name := range options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
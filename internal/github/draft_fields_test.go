@@ -0,0 +1,257 @@
+package github
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	g4 "github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+)
+
+const draftFieldsResponse = `{"data":{"node":{"fields":{"nodes":[` +
+	`{"__typename":"ProjectV2SingleSelectField","id":"release-field","name":"K8s Release","options":[` +
+	`{"id":"v133-id","name":"v1.33"},{"id":"v135-id","name":"v1.35"},{"id":"v134-id","name":"v1.34"}]},` +
+	`{"__typename":"ProjectV2SingleSelectField","id":"view-field","name":"View","options":[` +
+	`{"id":"triage-view-id","name":"triage"},{"id":"tracking-id","name":"issue-tracking"},{"id":"tracking-old-id","name":"old issue tracking"}]},` +
+	`{"__typename":"ProjectV2SingleSelectField","id":"status-field","name":"Status","options":[` +
+	`{"id":"drafting-id","name":"Drafting"},{"id":"draft-id","name":"Draft"},{"id":"done-id","name":"Done"}]},` +
+	`{"__typename":"ProjectV2SingleSelectField","id":"board-field","name":"Testgrid Board","options":[` +
+	`{"id":"blocking-id","name":"master-blocking"},{"id":"informing-id","name":"master-informing"}]}` +
+	`]}}}}`
+
+func TestDraftFields(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(draftFieldsResponse)) // nolint
+	}))
+	defer server.Close()
+
+	gh := &ProjectManager{projectID: PROJECT_ID, githubClient: g4.NewEnterpriseClient(server.URL, server.Client())}
+	resolved, err := gh.draftFields()
+	assert.NoError(t, err)
+	assert.Equal(t, fieldOption{"release-field", "v135-id"}, resolved.release)
+	assert.Equal(t, fieldOption{"view-field", "tracking-id"}, resolved.view)
+	assert.Equal(t, fieldOption{"status-field", "draft-id"}, resolved.status)
+	assert.Equal(t, fieldOption{"board-field", "blocking-id"}, gh.board(resolved, "sig-release-master-blocking"))
+	assert.Equal(t, fieldOption{"board-field", "informing-id"}, gh.board(resolved, "master-informing"))
+	assert.Equal(t, fieldOption{fieldID: "board-field"}, gh.board(resolved, "sig-node-release-blocking"))
+
+	// the derived values are stable and cached until the fields are queried again
+	for range 10 {
+		again, err := gh.draftFields()
+		assert.NoError(t, err)
+		assert.Same(t, resolved, again)
+		assert.Equal(t, fieldOption{"status-field", "draft-id"}, again.status)
+	}
+	assert.Equal(t, g4.ID("blocking-id"), resolved.boards["sig-release-master-blocking"])
+
+	gh.mu.Lock()
+	gh.fields = nil
+	gh.mu.Unlock()
+	refreshed, err := gh.draftFields()
+	assert.NoError(t, err)
+	assert.NotSame(t, resolved, refreshed)
+	assert.Equal(t, resolved.status, refreshed.status)
+	assert.Equal(t, 2, gh.Stats().FieldCacheMisses)
+}
+
+func TestReleaseField(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(draftFieldsResponse)) // nolint
+	}))
+	defer server.Close()
+
+	gh := &ProjectManager{projectID: PROJECT_ID, githubClient: g4.NewEnterpriseClient(server.URL, server.Client())}
+	resolved, err := gh.draftFields()
+	assert.NoError(t, err)
+	// the dashboard is ignored unless enabled
+	assert.Equal(t, fieldOption{"release-field", "v135-id"}, gh.releaseField(resolved, "sig-release-1.33-blocking"))
+
+	gh.releaseFromDashboard = true
+	tests := []struct {
+		dashboard string
+		expected  fieldOption
+	}{
+		{dashboard: "sig-release-1.33-blocking", expected: fieldOption{"release-field", "v133-id"}},
+		{dashboard: "sig-release-1.34-informing", expected: fieldOption{"release-field", "v134-id"}},
+		{dashboard: "sig-release-master-blocking", expected: fieldOption{"release-field", "v135-id"}},
+		// a release without option falls back to the latest one
+		{dashboard: "sig-release-1.29-blocking", expected: fieldOption{"release-field", "v135-id"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.dashboard, func(t *testing.T) {
+			assert.Equal(t, tt.expected, gh.releaseField(resolved, tt.dashboard))
+		})
+	}
+}
+
+func TestFirstOption(t *testing.T) {
+	options := map[string]interface{}{"Drafting": "drafting-id", "Draft": "draft-id", "Done": "done-id"}
+	for range 10 {
+		assert.Equal(t, g4.ID("draft-id"), firstOption(options, "drafting", "draft"))
+	}
+	assert.Nil(t, firstOption(options, "issue-tracking"))
+}
+
+func TestFieldOverrides(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(draftFieldsResponse)) // nolint
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		setFields  map[string]string
+		skipFields []string
+		expected   []fieldUpdate
+		wantErr    string
+	}{
+		{
+			name: "default fields",
+			expected: []fieldUpdate{
+				{fieldOption{"release-field", "v135-id"}, "K8s Release"},
+				{fieldOption{"view-field", "tracking-id"}, "View"},
+				{fieldOption{"status-field", "draft-id"}, "Status"},
+				{fieldOption{"board-field", "blocking-id"}, "Testgrid Board"},
+			},
+		},
+		{
+			name:       "overridden and skipped",
+			setFields:  map[string]string{"status": "done", "release-field": "v1.33"},
+			skipFields: []string{"View", "board-field"},
+			expected: []fieldUpdate{
+				{fieldOption{"release-field", "v133-id"}, "K8s Release"},
+				{fieldOption{"status-field", "done-id"}, "Status"},
+			},
+		},
+		{
+			name:       "skip wins over set",
+			setFields:  map[string]string{"View": "triage"},
+			skipFields: []string{"view", "Status", "K8s Release", "Testgrid Board"},
+			expected:   []fieldUpdate{},
+		},
+		{name: "unknown field", setFields: map[string]string{"Priority": "high"}, wantErr: `unknown field "Priority", valid values are: K8s Release, Status, Testgrid Board, View`},
+		{name: "unknown option", setFields: map[string]string{"Status": "Triage"}, wantErr: `unknown option "Triage" of field "Status", valid values are: Done, Draft, Drafting`},
+		{name: "unknown skipped field", skipFields: []string{"Priority"}, wantErr: `unknown field "Priority"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gh := &ProjectManager{projectID: PROJECT_ID, githubClient: g4.NewEnterpriseClient(server.URL, server.Client()),
+				setFields: tt.setFields, skipFields: tt.skipFields}
+			err := gh.ValidateFields()
+			if tt.wantErr != "" {
+				assert.ErrorIs(t, err, ErrFieldNotResolved)
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			resolved, err := gh.draftFields()
+			assert.NoError(t, err)
+			updates := resolved.updates(gh.releaseField(resolved, "master"), gh.board(resolved, "master-blocking"))
+			assert.Equal(t, tt.expected, updates)
+		})
+	}
+}
+
+func TestExtraReleases(t *testing.T) {
+	textFieldsResponse := `{"data":{"node":{"fields":{"nodes":[` +
+		`{"__typename":"ProjectV2Field","id":"release-text","name":"K8s Release","dataType":"TEXT"}]}}}}`
+	var texts []string
+	newServer := func(fields string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			data, _ := io.ReadAll(r.Body)
+			w.Header().Set("Content-Type", "application/json")
+			switch {
+			case strings.Contains(string(data), "addProjectV2DraftIssue"):
+				w.Write([]byte(`{"data":{"addProjectV2DraftIssue":{"projectItem":{"id":"PVTI_item","databaseId":1}}}}`)) // nolint
+			case strings.Contains(string(data), "updateProjectV2ItemFieldValue"):
+				var request struct {
+					Variables struct {
+						Input struct {
+							Value struct {
+								Text string `json:"text"`
+							} `json:"value"`
+						} `json:"input"`
+					} `json:"variables"`
+				}
+				assert.NoError(t, json.Unmarshal(data, &request))
+				texts = append(texts, request.Variables.Input.Value.Text)
+				w.Write([]byte(`{"data":{"updateProjectV2ItemFieldValue":{"clientMutationId":""}}}`)) // nolint
+			default:
+				w.Write([]byte(fields)) // nolint
+			}
+		}))
+	}
+
+	t.Run("single select field", func(t *testing.T) {
+		server := newServer(draftFieldsResponse)
+		defer server.Close()
+		gh := &ProjectManager{projectID: PROJECT_ID, githubClient: g4.NewEnterpriseClient(server.URL, server.Client()),
+			release: "1.32", extraReleases: []string{"1.31"}}
+		err := gh.ValidateFields()
+		assert.ErrorIs(t, err, ErrFieldNotResolved)
+		assert.ErrorContains(t, err, `field "K8s Release" is a single select field holding a single release, got the releases v1.32, v1.31`)
+	})
+
+	t.Run("text field", func(t *testing.T) {
+		server := newServer(textFieldsResponse)
+		defer server.Close()
+		gh := &ProjectManager{projectID: PROJECT_ID, githubClient: g4.NewEnterpriseClient(server.URL, server.Client()),
+			release: "1.32", extraReleases: []string{"1.31"}}
+		assert.NoError(t, gh.ValidateFields())
+		resolved, err := gh.draftFields()
+		assert.NoError(t, err)
+		assert.Equal(t, g4.ID("release-text"), resolved.releaseText)
+		assert.Equal(t, fieldOption{}, resolved.release)
+
+		_, err = gh.CreateDraftIssue("[Failing Test] Overall", "body", "master-blocking")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"v1.32, v1.31"}, texts)
+
+		gh.releaseFromDashboard = true
+		assert.Equal(t, "v1.33", gh.releaseTextOf("sig-release-1.33-blocking"))
+		assert.Equal(t, "v1.32, v1.31", gh.releaseTextOf("sig-release-master-blocking"))
+	})
+}
+
+func TestDateUpdates(t *testing.T) {
+	dateFieldsResponse := `{"data":{"node":{"fields":{"nodes":[` +
+		`{"__typename":"ProjectV2Field","id":"first-seen-field","name":"First Seen","dataType":"DATE"},` +
+		`{"__typename":"ProjectV2Field","id":"last-seen-text","name":"Last Seen","dataType":"TEXT"},` +
+		`{"__typename":"ProjectV2Field","id":"last-seen-field","name":"Last Seen On","dataType":"DATE"}]}}}}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(dateFieldsResponse)) // nolint
+	}))
+	defer server.Close()
+
+	gh := &ProjectManager{projectID: PROJECT_ID, githubClient: g4.NewEnterpriseClient(server.URL, server.Client())}
+	resolved, err := gh.draftFields()
+	assert.NoError(t, err)
+	// only the date fields are stamped
+	assert.Equal(t, g4.ID("first-seen-field"), resolved.firstSeen)
+	assert.Equal(t, g4.ID("last-seen-field"), resolved.lastSeen)
+
+	firstSeen := time.Date(2025, 9, 28, 6, 0, 0, 0, time.UTC)
+	now := time.Date(2025, 10, 1, 23, 30, 0, 0, time.FixedZone("PDT", -7*60*60))
+	assert.Equal(t, []dateUpdate{
+		{"first-seen-field", "First Seen", time.Date(2025, 9, 28, 0, 0, 0, 0, time.UTC)},
+		{"last-seen-field", "Last Seen", time.Date(2025, 10, 2, 0, 0, 0, 0, time.UTC)},
+	}, resolved.dateUpdates(firstSeen, now))
+	// an unknown first seen is left unset
+	assert.Equal(t, []dateUpdate{
+		{"last-seen-field", "Last Seen", time.Date(2025, 10, 2, 0, 0, 0, 0, time.UTC)},
+	}, resolved.dateUpdates(time.Time{}, now))
+
+	resolved.skipped = map[g4.ID]bool{"last-seen-field": true}
+	assert.Len(t, resolved.dateUpdates(firstSeen, now), 1)
+	assert.Empty(t, (&draftFields{}).dateUpdates(firstSeen, now))
+}
@@ -0,0 +1,143 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	g4 "github.com/shurcooL/githubv4"
+)
+
+// dateLayout is the ISO 8601 date accepted in the values of date fields.
+const dateLayout = "2006-01-02"
+
+// UpdateProjectItemFields sets the fields of an existing project item, e.g. to
+// refresh the failure count and the last updated date of a test still failing
+// instead of filing it again. The fields are keyed by their project field name,
+// matched ignoring case, and the values are converted to the field type:
+//   - number fields take an int or a float64
+//   - date fields take a time.Time or a string like 2025-10-01
+//   - text fields take a string
+//   - single select fields take the option name as a string
+//
+// Every value is resolved before the first update is sent, so an unknown field
+// or a mistyped value does not leave the item partially updated.
+func (g *ProjectManager) UpdateProjectItemFields(itemID string, fields map[string]interface{}) error {
+	if g.githubClient == nil {
+		return errors.New("github GraphQL client is nil")
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+
+	projectFields, err := g.GetProjectFields()
+	if err != nil {
+		return fmt.Errorf("failed to get project fields: %w", err)
+	}
+
+	// sort the names so the updates are sent in a stable order
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	type fieldUpdate struct {
+		name    string
+		fieldID g4.ID
+		value   g4.ProjectV2FieldValue
+	}
+	updates := make([]fieldUpdate, 0, len(names))
+	for _, name := range names {
+		field, ok := findField(projectFields, name)
+		if !ok {
+			return fmt.Errorf("%w: project has no field %q", ErrFieldNotResolved, name)
+		}
+		value, err := fieldValue(field, fields[name])
+		if err != nil {
+			return fmt.Errorf("invalid value for field %q: %w", name, err)
+		}
+		updates = append(updates, fieldUpdate{name: name, fieldID: field.ID, value: value})
+	}
+
+	var mutationUpdate struct {
+		UpdateProjectV2ItemFieldValue struct {
+			ClientMutationID string
+		} `graphql:"updateProjectV2ItemFieldValue(input: $input)"`
+	}
+	var errs []error
+	for _, update := range updates {
+		if err := g.mutate(g.context(), &mutationUpdate, g4.UpdateProjectV2ItemFieldValueInput{
+			ProjectID: g4.ID(g.projectID),
+			ItemID:    g4.ID(itemID),
+			FieldID:   update.fieldID,
+			Value:     update.value,
+		}); err != nil {
+			errs = append(errs, fmt.Errorf("failed to update %s field: %w", update.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// findField returns the project field with the name, ignoring case.
+func findField(fields []ProjectFieldInfo, name string) (ProjectFieldInfo, bool) {
+	for _, field := range fields {
+		if strings.EqualFold(strings.TrimSpace(string(field.Name)), strings.TrimSpace(name)) {
+			return field, true
+		}
+	}
+	return ProjectFieldInfo{}, false
+}
+
+// fieldValue converts the value to the field value input of the field type.
+func fieldValue(field ProjectFieldInfo, value interface{}) (g4.ProjectV2FieldValue, error) {
+	switch field.DataType {
+	case g4.ProjectV2FieldTypeNumber:
+		var number float64
+		switch v := value.(type) {
+		case int:
+			number = float64(v)
+		case float64:
+			number = v
+		default:
+			return g4.ProjectV2FieldValue{}, fmt.Errorf("expected a number, got %T", value)
+		}
+		return g4.ProjectV2FieldValue{Number: g4.NewFloat(g4.Float(number))}, nil
+	case g4.ProjectV2FieldTypeDate:
+		var date time.Time
+		switch v := value.(type) {
+		case time.Time:
+			date = v.UTC().Truncate(24 * time.Hour)
+		case string:
+			parsed, err := time.Parse(dateLayout, v)
+			if err != nil {
+				return g4.ProjectV2FieldValue{}, fmt.Errorf("expected a date like %s, got %q", dateLayout, v)
+			}
+			date = parsed
+		default:
+			return g4.ProjectV2FieldValue{}, fmt.Errorf("expected a date, got %T", value)
+		}
+		return g4.ProjectV2FieldValue{Date: g4.NewDate(g4.Date{Time: date})}, nil
+	case g4.ProjectV2FieldTypeText:
+		text, ok := value.(string)
+		if !ok {
+			return g4.ProjectV2FieldValue{}, fmt.Errorf("expected a string, got %T", value)
+		}
+		return g4.ProjectV2FieldValue{Text: g4.NewString(g4.String(text))}, nil
+	case g4.ProjectV2FieldTypeSingleSelect:
+		option, ok := value.(string)
+		if !ok {
+			return g4.ProjectV2FieldValue{}, fmt.Errorf("expected an option name, got %T", value)
+		}
+		for _, optName := range optionNames(field.Options) {
+			if strings.EqualFold(optName, option) {
+				optionID := g4.String(fmt.Sprintf("%s", field.Options[optName]))
+				return g4.ProjectV2FieldValue{SingleSelectOptionID: &optionID}, nil
+			}
+		}
+		return g4.ProjectV2FieldValue{}, fmt.Errorf("%w: unknown option %q", ErrFieldNotResolved, option)
+	}
+	return g4.ProjectV2FieldValue{}, fmt.Errorf("unsupported field type %q", field.DataType)
+}
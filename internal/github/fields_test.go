@@ -0,0 +1,91 @@
+package github
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	g4 "github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+)
+
+const projectFieldsResponse = `{"data":{"node":{"fields":{"nodes":[` +
+	`{"__typename":"ProjectV2Field","id":"failures-field","name":"Failure Count","dataType":"NUMBER"},` +
+	`{"__typename":"ProjectV2Field","id":"updated-field","name":"Last Updated","dataType":"DATE"},` +
+	`{"__typename":"ProjectV2Field","id":"notes-field","name":"Notes","dataType":"TEXT"},` +
+	`{"__typename":"ProjectV2SingleSelectField","id":"status-field","name":"Status","options":[{"id":"triage-id","name":"Triage"}]}` +
+	`]}}}}`
+
+func TestUpdateProjectItemFields(t *testing.T) {
+	var mutations []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(string(body), "mutation") {
+			mutations = append(mutations, string(body))
+			w.Write([]byte(`{"data":{"updateProjectV2ItemFieldValue":{"clientMutationId":""}}}`)) // nolint
+			return
+		}
+		w.Write([]byte(projectFieldsResponse)) // nolint
+	}))
+	defer server.Close()
+
+	gh := &ProjectManager{projectID: PROJECT_ID, githubClient: g4.NewEnterpriseClient(server.URL, server.Client())}
+	assert.NoError(t, gh.UpdateProjectItemFields("PVTI_item", map[string]interface{}{
+		"Failure Count": 3,
+		"Last Updated":  "2025-10-01",
+		"Notes":         "still failing",
+		"status":        "Triage",
+	}))
+	assert.Len(t, mutations, 4)
+	assert.Contains(t, mutations[0], `"fieldId":"failures-field"`)
+	assert.Contains(t, mutations[0], `"number":3`)
+	assert.Contains(t, mutations[1], `"date":"2025-10-01T00:00:00Z"`)
+	assert.Contains(t, mutations[2], `"text":"still failing"`)
+	assert.Contains(t, mutations[3], `"singleSelectOptionId":"triage-id"`)
+
+	// nothing is sent when a field can not be resolved
+	mutations = nil
+	err := gh.UpdateProjectItemFields("PVTI_item", map[string]interface{}{"Failure Count": 1, "Owner": "me"})
+	assert.ErrorIs(t, err, ErrFieldNotResolved)
+	assert.Empty(t, mutations)
+}
+
+func TestFieldValue(t *testing.T) {
+	number := ProjectFieldInfo{DataType: g4.ProjectV2FieldTypeNumber}
+	date := ProjectFieldInfo{DataType: g4.ProjectV2FieldTypeDate}
+	status := ProjectFieldInfo{DataType: g4.ProjectV2FieldTypeSingleSelect, Options: map[string]interface{}{"Triage": "triage-id"}}
+	tests := []struct {
+		name    string
+		field   ProjectFieldInfo
+		value   interface{}
+		wantErr string
+	}{
+		{name: "int number", field: number, value: 2},
+		{name: "float number", field: number, value: 2.5},
+		{name: "string number", field: number, value: "2", wantErr: "expected a number"},
+		{name: "time date", field: date, value: time.Date(2025, 10, 1, 15, 4, 5, 0, time.UTC)},
+		{name: "malformed date", field: date, value: "10/01/2025", wantErr: "expected a date"},
+		{name: "text with a number", field: ProjectFieldInfo{DataType: g4.ProjectV2FieldTypeText}, value: 1, wantErr: "expected a string"},
+		{name: "unknown option", field: status, value: "Done", wantErr: "unknown option"},
+		{name: "unsupported type", field: ProjectFieldInfo{DataType: g4.ProjectV2FieldTypeAssignees}, value: "me", wantErr: "unsupported field type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := fieldValue(tt.field, tt.value)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+
+	value, err := fieldValue(date, time.Date(2025, 10, 1, 15, 4, 5, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC), value.Date.Time)
+}
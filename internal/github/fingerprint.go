@@ -0,0 +1,90 @@
+package github
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	g4 "github.com/shurcooL/githubv4"
+)
+
+// fingerprintPattern matches the hidden fingerprint of an issue body.
+var fingerprintPattern = regexp.MustCompile(`<!--\s*signalhound-key:\s*(.+?)\s*-->`)
+
+// fingerprintEscaper keeps a key from closing the HTML comment early.
+var (
+	fingerprintEscaper   = strings.NewReplacer("-->", "--&gt;")
+	fingerprintUnescaper = strings.NewReplacer("--&gt;", "-->")
+)
+
+// Fingerprint returns the hidden marker embedded in an issue body for a test
+// key, e.g. <!-- signalhound-key: dashboard/tab/test -->, so the issue of a
+// test is found again even when its title was edited.
+func Fingerprint(key string) string {
+	return fmt.Sprintf("<!-- signalhound-key: %s -->", fingerprintEscaper.Replace(key))
+}
+
+// ParseFingerprint returns the test key of the first fingerprint in the body.
+func ParseFingerprint(body string) (string, bool) {
+	match := fingerprintPattern.FindStringSubmatch(body)
+	if match == nil {
+		return "", false
+	}
+	return fingerprintUnescaper.Replace(match[1]), true
+}
+
+// ListFingerprints returns the node ID of the project items by the test key
+// fingerprinted in their body, for draft issues and issues. Items without a
+// fingerprint are skipped.
+func (g *ProjectManager) ListFingerprints() (map[string]string, error) {
+	variables := map[string]interface{}{
+		"projectID": g4.ID(g.projectID),
+		"cursor":    (*g4.String)(nil),
+	}
+
+	fingerprints := map[string]string{}
+	for {
+		var query struct {
+			rateLimit
+			Node struct {
+				ProjectV2 struct {
+					Items struct {
+						Nodes []struct {
+							ID      g4.ID
+							Content struct {
+								DraftIssue struct {
+									Body string
+								} `graphql:"... on DraftIssue"`
+								Issue struct {
+									Body string
+								} `graphql:"... on Issue"`
+							}
+						}
+						PageInfo struct {
+							HasNextPage bool
+							EndCursor   g4.String
+						}
+					} `graphql:"items(first: 100, after: $cursor)"`
+				} `graphql:"... on ProjectV2"`
+			} `graphql:"node(id: $projectID)"`
+		}
+		if err := g.query(g.context(), &query, variables); err != nil {
+			return nil, fmt.Errorf("failed to query project items: %w", wrapNotFound(err))
+		}
+		items := query.Node.ProjectV2.Items
+		for _, item := range items.Nodes {
+			body := item.Content.DraftIssue.Body
+			if body == "" {
+				body = item.Content.Issue.Body
+			}
+			if key, ok := ParseFingerprint(body); ok {
+				fingerprints[key], _ = item.ID.(string)
+			}
+		}
+		if !items.PageInfo.HasNextPage {
+			return fingerprints, nil
+		}
+		cursor := items.PageInfo.EndCursor
+		variables["cursor"] = &cursor
+	}
+}
@@ -0,0 +1,67 @@
+package github
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	g4 "github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFingerprint(t *testing.T) {
+	tests := []struct {
+		name string
+		key  string
+	}{
+		{name: "test key", key: "sig-release-master-blocking/build-master/ci-kubernetes-build.overall"},
+		{name: "key closing the comment", key: "dashboard/tab/test --> injected"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := "### Which jobs are failing?\n\n" + Fingerprint(tt.key) + "\n"
+			assert.Equal(t, 1, len(fingerprintPattern.FindAllString(body, -1)))
+			key, ok := ParseFingerprint(body)
+			assert.True(t, ok)
+			assert.Equal(t, tt.key, key)
+		})
+	}
+
+	_, ok := ParseFingerprint("an issue filed by hand")
+	assert.False(t, ok)
+}
+
+func TestListFingerprints(t *testing.T) {
+	pages := map[string]string{
+		"": `{"data":{"node":{"items":{"nodes":[` +
+			`{"id":"item-1","content":{"body":"` + Fingerprint("board/tab/one") + `"}},` +
+			`{"id":"item-2","content":{"body":"filed by hand"}}` +
+			`],"pageInfo":{"hasNextPage":true,"endCursor":"page-2"}}}}}`,
+		"page-2": `{"data":{"node":{"items":{"nodes":[` +
+			`{"id":"item-3","content":{"body":"` + Fingerprint("board/tab/two") + `"}}` +
+			`],"pageInfo":{"hasNextPage":false,"endCursor":"page-3"}}}}}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Variables struct {
+				Cursor *string `json:"cursor"`
+			} `json:"variables"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		var cursor string
+		if request.Variables.Cursor != nil {
+			cursor = *request.Variables.Cursor
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(pages[cursor])) // nolint
+	}))
+	defer server.Close()
+
+	gh := &ProjectManager{projectID: PROJECT_ID, githubClient: g4.NewEnterpriseClient(server.URL, server.Client())}
+	fingerprints, err := gh.ListFingerprints()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"board/tab/one": "item-1", "board/tab/two": "item-3"}, fingerprints)
+	assert.Equal(t, 2, gh.Stats().Queries)
+}
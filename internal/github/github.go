@@ -4,9 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	g4 "github.com/shurcooL/githubv4"
 	"golang.org/x/oauth2"
@@ -17,9 +23,72 @@ const (
 	ORGANIZATION = "kubernetes"
 )
 
+var (
+	// ErrTokenMissing is returned when a GitHub token is required but not set.
+	ErrTokenMissing = errors.New("GitHub token required")
+	// ErrProjectNotFound is returned when the project can not be resolved.
+	ErrProjectNotFound = errors.New("project not found")
+	// ErrTrackingIssueNotFound is returned when the tracking issue can not be
+	// resolved, missing or not accessible with the token.
+	ErrTrackingIssueNotFound = errors.New("tracking issue not found")
+	// ErrFieldNotResolved is returned when a project field or option can not be matched.
+	ErrFieldNotResolved = errors.New("project field not resolved")
+	// ErrNoProjectFields is returned when the project fields are still empty
+	// after the retries.
+	ErrNoProjectFields = errors.New("no project fields returned")
+)
+
+// emptyFieldsDelay is the wait before querying again the project fields
+// returned empty, GitHub intermittently answers a valid project without them.
+const emptyFieldsDelay = 2 * time.Second
+
+// Field roles are the project fields set on a created draft issue.
+const (
+	FieldRelease = "release"
+	FieldView    = "view"
+	FieldStatus  = "status"
+	FieldBoard   = "board"
+	// FieldFirstSeen and FieldLastSeen are date fields, only set when the
+	// project has them.
+	FieldFirstSeen = "first-seen"
+	FieldLastSeen  = "last-seen"
+)
+
+// FieldRoles are the roles accepted in Options.FieldNames.
+var FieldRoles = []string{FieldRelease, FieldView, FieldStatus, FieldBoard, FieldFirstSeen, FieldLastSeen}
+
+// defaultFieldMatches are the substrings matched in the lowercased field
+// names when a role is not mapped to a field name.
+var defaultFieldMatches = map[string]string{
+	FieldRelease: "k8s release",
+	FieldView:    "view",
+	FieldStatus:  "status",
+	FieldBoard:   "board",
+
+	FieldFirstSeen: "first seen",
+	FieldLastSeen:  "last seen",
+}
+
+const (
+	// DefaultFieldsPageSize is the number of project fields queried by default.
+	DefaultFieldsPageSize = 50
+	// MaxFieldsPageSize is the most nodes GitHub returns in a connection page.
+	MaxFieldsPageSize = 100
+)
+
+// notFoundMessage is the GraphQL error message of a node ID that does not exist.
+const notFoundMessage = "Could not resolve to"
+
 type ProjectManagerInterface interface {
 	GetProjectFields() ([]ProjectFieldInfo, error)
-	CreateDraftIssue(title, body, board string) error
+	CreateDraftIssue(title, body, board string) (*DraftIssue, error)
+	CreateDraftIssues(requests []DraftIssueRequest) ([]*DraftIssue, error)
+	ValidateBoard(board string) error
+	ValidateFields() error
+	UpdateProjectItemFields(itemID string, fields map[string]interface{}) error
+	ListFingerprints() (map[string]string, error)
+	UpsertRollupComment(issue TrackingIssue, body string) (string, error)
+	Stats() Stats
 }
 
 // ProjectManager represents a GitHub organization with a global workflow file and reference
@@ -30,39 +99,457 @@ type ProjectManager struct {
 	// projectID is the ID of the Kubernetes version project board
 	projectID string
 
-	// fields is a map of project field names to their IDs
-	fields map[string]ProjectFieldInfo
+	// release is the release cycle set on draft issues, empty for the latest option
+	release string
+
+	// extraReleases are the other release cycles of an overlap window
+	extraReleases []string
+
+	// releaseFromDashboard sets the release of the versioned dashboards instead
+	releaseFromDashboard bool
+
+	// fieldNames maps a field role to the exact project field name
+	fieldNames map[string]string
+
+	// setFields and skipFields override the fields set on draft issues
+	setFields  map[string]string
+	skipFields []string
 
-	// githubClient is the official GitHub API v4 (GraphQL) client
+	// fields caches the project fields with their options, nil until fetched
+	fields []ProjectFieldInfo
+
+	// resolved caches the fields set on draft issues, derived from fields and
+	// reset when they are fetched again
+	resolved *draftFields
+
+	// githubClient is the official GitHub API v4 (GraphQL) client of the token in use
 	githubClient *g4.Client
+
+	// clients are the clients of every token, in rotation order, when more
+	// than one token is configured
+	clients []*g4.Client
+
+	// active is the index of githubClient in clients
+	active int
+
+	// emptyFieldsRetries is the number of times the project fields returned
+	// empty are queried again, waiting emptyFieldsDelay before each retry
+	emptyFieldsRetries int
+	emptyFieldsDelay   time.Duration
+
+	// timeout is the deadline of each query and mutation, 0 for none
+	timeout time.Duration
+
+	// fieldsPageSize is the number of project fields queried, 0 for
+	// DefaultFieldsPageSize
+	fieldsPageSize int
+
+	// maxBodyLength is the number of characters the draft issue bodies are
+	// truncated to, 0 for none
+	maxBodyLength int
+
+	// ctx is the context of NewProjectManager, canceling it aborts the
+	// GitHub calls in flight and the next ones
+	ctx context.Context
+
+	// mu guards the fields cache, the active client and the stats
+	mu    sync.Mutex
+	stats Stats
+}
+
+// Stats counts the GitHub API calls made by a ProjectManager
+type Stats struct {
+	// Queries is the number of GraphQL queries sent.
+	Queries int
+	// Mutations is the number of GraphQL mutations sent.
+	Mutations int
+	// FieldCacheHits is the number of project fields lookups served from the cache.
+	FieldCacheHits int
+	// FieldCacheMisses is the number of project fields lookups sent to GitHub.
+	FieldCacheMisses int
+	// TokenRotations is the number of switches to the next token on a rate limit.
+	TokenRotations int
+	// QueryCost is the total cost of the GraphQL queries in rate limit points,
+	// as reported by GitHub. Mutations report no cost.
+	QueryCost int
+	// RateLimitRemaining is the number of points left of the hourly budget
+	// after the last query.
+	RateLimitRemaining int
+}
+
+// rateLimit is embedded in the GraphQL queries so GitHub reports their cost
+// and the points left of the hourly budget. The mutations have no rateLimit
+// field.
+type rateLimit struct {
+	RateLimit struct {
+		Cost      int
+		Remaining int
+	}
+}
+
+func (r *rateLimit) rateLimitCost() (cost, remaining int) {
+	return r.RateLimit.Cost, r.RateLimit.Remaining
+}
+
+// rateLimited is a query embedding rateLimit
+type rateLimited interface {
+	rateLimitCost() (cost, remaining int)
+}
+
+// DraftIssue is a draft issue created in the project
+type DraftIssue struct {
+	// ItemID is the node ID of the project item.
+	ItemID string
+	// URL is the link to the item in the project.
+	URL string
+}
+
+// DraftIssueRequest is a draft issue to create with CreateDraftIssues
+type DraftIssueRequest struct {
+	Title string
+	Body  string
+	// Board is the Testgrid Board option or the originating dashboard name.
+	Board string
+	// Dashboard is the dashboard of the test, its release sets the K8s
+	// Release field with Options.ReleaseFromDashboard. Defaults to Board.
+	Dashboard string
+	// FirstSeen is when the test was first observed failing, stamped on the
+	// First Seen date field, which is left unset when zero. The Last Seen
+	// date field is stamped with the filing time.
+	FirstSeen time.Time
 }
 
 // ProjectFieldInfo represents a project field with its options
 type ProjectFieldInfo struct {
-	ID      g4.ID
-	Name    g4.String
-	Options map[string]interface{} // option name -> option ID
+	ID       g4.ID
+	Name     g4.String
+	DataType g4.ProjectV2FieldType
+	Options  map[string]interface{} // option name -> option ID
+}
+
+// Options holds the optional settings used to build a ProjectManager
+type Options struct {
+	// ProjectID is either the project node ID (PVT_...) or the project
+	// number in the organization, defaults to PROJECT_ID.
+	ProjectID string
+	// FieldNames maps a field role, one of FieldRoles, to the name or the ID
+	// of the project field, e.g. release: "Target Version", the ID picks one
+	// of several fields with the same name. Unmapped roles fall back to
+	// matching the default field names.
+	FieldNames map[string]string
+	// Release is the release cycle, e.g. 1.35, set on the K8s Release field,
+	// see ResolveRelease. Empty picks the latest release option of the field.
+	Release string
+	// ExtraReleases are the other release cycles tracked during an overlap
+	// window, e.g. 1.31 in stabilization while Release is 1.32. They are only
+	// set when the K8s Release field holds several values, a text field set to
+	// every release, and ValidateFields fails for a single select field.
+	ExtraReleases []string
+	// RotationTokens are additional tokens used in turn, the next one is
+	// picked when the token in use hits a rate limit.
+	RotationTokens []string
+	// HTTPClient is the client the authenticated GraphQL requests are sent
+	// with, e.g. trusting a corporate CA, defaults to http.DefaultClient.
+	HTTPClient *http.Client
+	// EmptyFieldsRetries is the number of times the project fields are
+	// queried again when GitHub returns none, 0 fails on the first empty
+	// response.
+	EmptyFieldsRetries int
+	// ReleaseFromDashboard sets the K8s Release field of a draft issue from
+	// the version of its dashboard, e.g. 1.32 for sig-release-1.32-blocking,
+	// falling back to Release for the unversioned dashboards like master.
+	ReleaseFromDashboard bool
+	// SetFields maps a single select field, by name or ID, to the option set
+	// on the draft issues, e.g. Status: Triage, overriding the K8s Release,
+	// View, Status and Testgrid Board options or setting another field.
+	SetFields map[string]string
+	// SkipFields are the fields, by name or ID, left at the board defaults on
+	// the draft issues, they win over SetFields.
+	SkipFields []string
+	// FieldsPageSize is the number of project fields queried, between 1 and
+	// MaxFieldsPageSize, 0 for DefaultFieldsPageSize. The fields beyond it
+	// are not resolved on the draft issues.
+	FieldsPageSize int
+	// MaxBodyLength is the maximum number of characters of a draft issue
+	// body, longer bodies are truncated keeping their start, see
+	// DefaultMaxBodyLength. 0 disables it.
+	MaxBodyLength int
+	// Timeout is the deadline of each GraphQL query and mutation, a hung
+	// GitHub request fails with an error wrapping context.DeadlineExceeded.
+	// 0 disables it.
+	Timeout time.Duration
+}
+
+// validateFieldNames verifies every mapped role is known and has a field name.
+func validateFieldNames(fieldNames map[string]string) error {
+	for role, name := range fieldNames {
+		if _, ok := defaultFieldMatches[role]; !ok {
+			return fmt.Errorf("unknown field role %q, valid values are: %s", role, strings.Join(FieldRoles, ", "))
+		}
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("field role %q is mapped to an empty field name", role)
+		}
+	}
+	return nil
 }
 
-// NewProjectManager creates a new ProjectManager
-func NewProjectManager(ctx context.Context, token string) ProjectManagerInterface {
-	return &ProjectManager{
+// projectIDPattern matches a GitHub ProjectV2 node ID, e.g. PVT_kwDOAM_34M4AAThW
+var projectIDPattern = regexp.MustCompile(`^PVT_[A-Za-z0-9_-]+$`)
+
+// NewProjectManager creates a new ProjectManager, a project number is resolved
+// to its node ID with a lookup in the organization. Every GitHub call of the
+// ProjectManager is sent with the context, canceling it aborts them.
+func NewProjectManager(ctx context.Context, token string, opts Options) (ProjectManagerInterface, error) {
+	if token == "" {
+		return nil, ErrTokenMissing
+	}
+	if err := validateFieldNames(opts.FieldNames); err != nil {
+		return nil, err
+	}
+	if opts.FieldsPageSize < 0 || opts.FieldsPageSize > MaxFieldsPageSize {
+		return nil, fmt.Errorf("fields page size must be between 1 and %d, got %d", MaxFieldsPageSize, opts.FieldsPageSize)
+	}
+	// the oauth2 clients send the requests with the transport of the client
+	// of the context
+	if opts.HTTPClient != nil {
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, opts.HTTPClient)
+	}
+	manager := &ProjectManager{
 		organization: ORGANIZATION,
-		projectID:    PROJECT_ID,
-		fields:       map[string]ProjectFieldInfo{},
-		githubClient: g4.NewClient(oauth2.NewClient(
-			ctx, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token}),
-		)),
+		projectID:    strings.TrimSpace(opts.ProjectID),
+		fieldNames:   opts.FieldNames,
+		release:      opts.Release,
+		githubClient: newClient(ctx, token),
+
+		extraReleases: opts.ExtraReleases,
+
+		releaseFromDashboard: opts.ReleaseFromDashboard,
+		setFields:            opts.SetFields,
+		skipFields:           opts.SkipFields,
+
+		emptyFieldsRetries: opts.EmptyFieldsRetries,
+		emptyFieldsDelay:   emptyFieldsDelay,
+		timeout:            opts.Timeout,
+		fieldsPageSize:     opts.FieldsPageSize,
+		maxBodyLength:      opts.MaxBodyLength,
+		ctx:                ctx,
+	}
+	if len(opts.RotationTokens) > 0 {
+		manager.clients = []*g4.Client{manager.githubClient}
+		for _, rotationToken := range opts.RotationTokens {
+			manager.clients = append(manager.clients, newClient(ctx, rotationToken))
+		}
+	}
+	if manager.projectID == "" {
+		manager.projectID = PROJECT_ID
 	}
+
+	if number, ok := parseProjectNumber(manager.projectID); ok {
+		projectID, err := manager.resolveProjectNumber(ctx, number)
+		if err != nil {
+			return nil, err
+		}
+		manager.projectID = projectID
+	}
+
+	if !isValidProjectID(manager.projectID) {
+		return nil, fmt.Errorf("invalid project ID %q: expected a project node ID like PVT_... or a project number", manager.projectID)
+	}
+	return manager, nil
 }
 
-// GetProjectFields queries the project fields and their options
+// isValidProjectID verifies the ID looks like a ProjectV2 node ID.
+func isValidProjectID(projectID string) bool {
+	return projectIDPattern.MatchString(projectID)
+}
+
+// parseProjectNumber returns the project number when the input is numeric.
+func parseProjectNumber(projectID string) (int, bool) {
+	number, err := strconv.Atoi(projectID)
+	if err != nil || number <= 0 {
+		return 0, false
+	}
+	return number, true
+}
+
+// resolveProjectNumber looks up the node ID of a project number in the organization.
+func (g *ProjectManager) resolveProjectNumber(ctx context.Context, number int) (string, error) {
+	var query struct {
+		rateLimit
+		Organization struct {
+			ProjectV2 struct {
+				ID g4.ID
+			} `graphql:"projectV2(number: $number)"`
+		} `graphql:"organization(login: $organization)"`
+	}
+
+	variables := map[string]interface{}{
+		"organization": g4.String(g.organization),
+		"number":       g4.Int(number),
+	}
+
+	if err := g.query(ctx, &query, variables); err != nil {
+		return "", fmt.Errorf("failed to resolve project number %d: %w", number, wrapNotFound(err))
+	}
+
+	projectID, _ := query.Organization.ProjectV2.ID.(string)
+	if projectID == "" {
+		return "", fmt.Errorf("%w: project number %d in %s organization", ErrProjectNotFound, number, g.organization)
+	}
+	return projectID, nil
+}
+
+// matchesField reports whether the project field has the role, either by its
+// mapped name or ID, or by the default substring match. Mapping the ID picks
+// one of several fields with the same name.
+func (g *ProjectManager) matchesField(role string, field ProjectFieldInfo) bool {
+	if mapped, ok := g.fieldNames[role]; ok {
+		mapped = strings.TrimSpace(mapped)
+		return mapped == fmt.Sprintf("%v", field.ID) || strings.EqualFold(mapped, strings.TrimSpace(string(field.Name)))
+	}
+	return strings.Contains(strings.ToLower(string(field.Name)), defaultFieldMatches[role])
+}
+
+// duplicateFieldNames returns the names shared by several project fields,
+// ignoring case, sorted.
+func duplicateFieldNames(fields []ProjectFieldInfo) []string {
+	counts := map[string]int{}
+	for _, field := range fields {
+		counts[strings.ToLower(strings.TrimSpace(string(field.Name)))]++
+	}
+	var duplicates []string
+	for name, count := range counts {
+		if count > 1 {
+			duplicates = append(duplicates, name)
+		}
+	}
+	sort.Strings(duplicates)
+	return duplicates
+}
+
+// Stats returns the counters of the GitHub API calls made so far.
+func (g *ProjectManager) Stats() Stats {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.stats
+}
+
+// query sends a GraphQL query counted in the stats, each attempt on a
+// rotated token is counted. The cost of a query embedding rateLimit is logged
+// and added to the stats.
+func (g *ProjectManager) query(ctx context.Context, q interface{}, variables map[string]interface{}) error {
+	return g.withFailover(func(client *g4.Client) error {
+		g.mu.Lock()
+		g.stats.Queries++
+		g.mu.Unlock()
+		ctx, cancel := g.callContext(ctx)
+		defer cancel()
+		if err := g.timedOut(ctx, client.Query(ctx, q, variables)); err != nil {
+			return err
+		}
+		if limited, ok := q.(rateLimited); ok {
+			cost, remaining := limited.rateLimitCost()
+			slog.Debug("github graphql query cost", "cost", cost, "remaining", remaining)
+			g.mu.Lock()
+			g.stats.QueryCost += cost
+			g.stats.RateLimitRemaining = remaining
+			g.mu.Unlock()
+		}
+		return nil
+	})
+}
+
+// mutate sends a GraphQL mutation counted in the stats, each attempt on a
+// rotated token is counted.
+func (g *ProjectManager) mutate(ctx context.Context, m interface{}, input g4.Input) error {
+	return g.withFailover(func(client *g4.Client) error {
+		g.mu.Lock()
+		g.stats.Mutations++
+		g.mu.Unlock()
+		ctx, cancel := g.callContext(ctx)
+		defer cancel()
+		return g.timedOut(ctx, client.Mutate(ctx, m, input, nil))
+	})
+}
+
+// context returns the context of the GitHub calls, the one of
+// NewProjectManager.
+func (g *ProjectManager) context() context.Context {
+	if g.ctx == nil {
+		return context.Background()
+	}
+	return g.ctx
+}
+
+// callContext returns the context of a single GraphQL call, canceled after
+// the timeout.
+func (g *ProjectManager) callContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if g.timeout > 0 {
+		return context.WithTimeout(ctx, g.timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// timedOut replaces the error of a call past its deadline with the deadline
+// error, the HTTP error of a canceled request does not tell why.
+func (g *ProjectManager) timedOut(ctx context.Context, err error) error {
+	if err != nil && g.timeout > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("GitHub did not answer within %s: %w", g.timeout, ctx.Err())
+	}
+	return err
+}
+
+// GetProjectFields returns the project fields and their options, they are
+// queried once and cached for the lifetime of the ProjectManager. An empty
+// list of fields is queried again up to the empty fields retries, then fails
+// with ErrNoProjectFields, so draft issues are never created without fields.
 func (g *ProjectManager) GetProjectFields() ([]ProjectFieldInfo, error) {
 	if g.githubClient == nil {
 		return nil, errors.New("github GraphQL client is nil")
 	}
 
+	g.mu.Lock()
+	if g.fields != nil {
+		g.stats.FieldCacheHits++
+		g.mu.Unlock()
+		return g.fields, nil
+	}
+	g.stats.FieldCacheMisses++
+	g.mu.Unlock()
+
+	fields, err := g.queryProjectFields()
+	for retry := 0; err == nil && len(fields) == 0 && retry < g.emptyFieldsRetries; retry++ {
+		slog.Debug("project fields returned empty, retrying", "project", g.projectID, "retry", retry+1, "delay", g.emptyFieldsDelay)
+		select {
+		case <-time.After(g.emptyFieldsDelay):
+		case <-g.context().Done():
+			return nil, g.context().Err()
+		}
+		fields, err = g.queryProjectFields()
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("%w: project %s, it may be briefly unavailable on GitHub, try again", ErrNoProjectFields, g.projectID)
+	}
+	// the last field of a duplicate name wins, unless its role is mapped to an ID
+	if duplicates := duplicateFieldNames(fields); len(duplicates) > 0 {
+		slog.Warn("several project fields have the same name, map their role to the field ID to pick one",
+			"project", g.projectID, "names", duplicates)
+	}
+
+	g.mu.Lock()
+	g.fields = fields
+	g.resolved = nil
+	g.mu.Unlock()
+	return fields, nil
+}
+
+// queryProjectFields sends the query of the project fields, without caching.
+func (g *ProjectManager) queryProjectFields() ([]ProjectFieldInfo, error) {
 	var query struct {
+		rateLimit
 		Node struct {
 			ProjectV2 struct {
 				Fields struct {
@@ -82,18 +569,32 @@ func (g *ProjectManager) GetProjectFields() ([]ProjectFieldInfo, error) {
 							ID   g4.ID
 							Name g4.String
 						} `graphql:"... on ProjectV2IterationField"`
+						// Number, date and text fields
+						ProjectV2Field struct {
+							ID       g4.ID
+							Name     g4.String
+							DataType g4.ProjectV2FieldType
+						} `graphql:"... on ProjectV2Field"`
 					}
-				} `graphql:"fields(first: 50)"`
+				} `graphql:"fields(first: $fieldsPageSize)"`
 			} `graphql:"... on ProjectV2"`
 		} `graphql:"node(id: $projectID)"`
 	}
 
+	pageSize := g.fieldsPageSize
+	if pageSize == 0 {
+		pageSize = DefaultFieldsPageSize
+	}
 	variables := map[string]interface{}{
-		"projectID": g4.ID(g.projectID),
+		"projectID":      g4.ID(g.projectID),
+		"fieldsPageSize": g4.Int(pageSize),
 	}
 
-	if err := g.githubClient.Query(context.Background(), &query, variables); err != nil {
-		return nil, fmt.Errorf("failed to query project fields: %w", err)
+	if err := g.query(g.context(), &query, variables); err != nil {
+		if !isPartialData(err) || len(query.Node.ProjectV2.Fields.Nodes) == 0 {
+			return nil, fmt.Errorf("failed to query project fields: %w", wrapNotFound(err))
+		}
+		slog.Warn("project fields partially resolved, using the fields returned", "project", g.projectID, "error", err)
 	}
 
 	fields := make([]ProjectFieldInfo, 0, len(query.Node.ProjectV2.Fields.Nodes))
@@ -101,6 +602,7 @@ func (g *ProjectManager) GetProjectFields() ([]ProjectFieldInfo, error) {
 	for _, node := range query.Node.ProjectV2.Fields.Nodes {
 		var fieldID g4.ID
 		var fieldName g4.String
+		var dataType g4.ProjectV2FieldType
 		options := make(map[string]interface{})
 
 		// Handle different field types based on __typename
@@ -108,192 +610,228 @@ func (g *ProjectManager) GetProjectFields() ([]ProjectFieldInfo, error) {
 		case "ProjectV2SingleSelectField":
 			fieldID = node.ProjectV2SingleSelectField.ID
 			fieldName = node.ProjectV2SingleSelectField.Name
+			dataType = g4.ProjectV2FieldTypeSingleSelect
 			for _, opt := range node.ProjectV2SingleSelectField.Options {
 				options[string(opt.Name)] = opt.ID
 			}
 		case "ProjectV2IterationField":
 			fieldID = node.ProjectV2IterationField.ID
 			fieldName = node.ProjectV2IterationField.Name
+			dataType = g4.ProjectV2FieldTypeIteration
+		case "ProjectV2Field":
+			fieldID = node.ProjectV2Field.ID
+			fieldName = node.ProjectV2Field.Name
+			dataType = node.ProjectV2Field.DataType
 		default:
 			continue
 		}
 
 		fields = append(fields, ProjectFieldInfo{
-			ID:      fieldID,
-			Name:    fieldName,
-			Options: options,
+			ID:       fieldID,
+			Name:     fieldName,
+			DataType: dataType,
+			Options:  options,
 		})
 	}
-
 	return fields, nil
 }
 
 // CreateDraftIssue creates a new issue draft issue in the board with a
-// specific test issue template.
-func (g *ProjectManager) CreateDraftIssue(title, body, board string) error {
+// specific test issue template. The board is either the Testgrid Board option
+// or the originating dashboard name, e.g. sig-release-master-blocking.
+func (g *ProjectManager) CreateDraftIssue(title, body, board string) (*DraftIssue, error) {
+	return g.createDraftIssue(DraftIssueRequest{Title: title, Body: body, Board: board})
+}
+
+// createDraftIssue creates the draft issue of the request.
+func (g *ProjectManager) createDraftIssue(request DraftIssueRequest) (*DraftIssue, error) {
 	if g.githubClient == nil {
-		return errors.New("github GraphQL client is nil")
+		return nil, errors.New("github GraphQL client is nil")
 	}
-
-	// first, get the project fields to find the correct field IDs and option IDs
-	fields, err := g.GetProjectFields()
-	if err != nil {
-		return fmt.Errorf("failed to get project fields: %w", err)
+	title, body, board := request.Title, request.Body, request.Board
+	dashboard := request.Dashboard
+	if dashboard == "" {
+		dashboard = board
 	}
 
-	// find the fields we need
-	var k8sReleaseFieldID, viewFieldID, statusFieldID, boardFieldID g4.ID
-	var k8sReleaseValueID, viewValueID, statusValueID, boardValueID g4.ID
-
-	for _, field := range fields {
-		fieldNameLower := strings.ToLower(string(field.Name))
-
-		// find K8s Release field - look for fields containing "k8s", "release", or "version"
-		if strings.Contains(fieldNameLower, "k8s release") {
-			k8sReleaseFieldID = field.ID
-			// find the latest version option (highest version number)
-			latestVersion := ""
-			latestVersionID := g4.ID("")
-			for optName, optID := range field.Options {
-				// extract version number from option name (e.g., "v1.32" -> "1.32")
-				if version := extractVersion(optName); version != "" {
-					if latestVersion == "" || compareVersions(version, latestVersion) > 0 {
-						latestVersion = version
-						latestVersionID = optID
-					}
-				}
-			}
-			if latestVersionID != g4.ID("") {
-				k8sReleaseValueID = latestVersionID
-			}
-		}
-
-		// find view field - look for fields containing "view"
-		if strings.Contains(fieldNameLower, "view") {
-			viewFieldID = field.ID
-			// find "issue-tracking" option
-			for optName, optID := range field.Options {
-				if strings.Contains(strings.ToLower(optName), "issue-tracking") ||
-					strings.Contains(strings.ToLower(optName), "issue tracking") {
-					viewValueID = optID
-					break
-				}
-			}
-		}
-
-		// find the board field, master-informing or master-blocking
-		if strings.Contains(fieldNameLower, "board") {
-			boardFieldID = field.ID
-			for optName, optID := range field.Options {
-				if strings.Contains(board, strings.ToLower(optName)) {
-					boardValueID = optID
-					break
-				}
-			}
-		}
-
-		// find Status field
-		if strings.Contains(fieldNameLower, "status") {
-			statusFieldID = field.ID
-			for optName, optID := range field.Options {
-				if strings.Contains(strings.ToLower(optName), "drafting") ||
-					strings.Contains(strings.ToLower(optName), "draft") {
-					statusValueID = optID
-					break
-				}
-			}
-		}
+	// first, resolve the field IDs and option IDs from the project fields
+	resolved, err := g.draftFields()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project fields: %w", err)
 	}
+	boardField := g.board(resolved, board)
+	releaseField := g.releaseField(resolved, dashboard)
 
 	// create the draft issue
 	var mutationDraft struct {
 		AddProjectV2DraftIssue struct {
 			ProjectItem struct {
-				ID g4.ID
+				ID         g4.ID
+				DatabaseID int `graphql:"databaseId"`
+				Project    struct {
+					URL string `graphql:"url"`
+				}
 			}
 		} `graphql:"addProjectV2DraftIssue(input: $input)"`
 	}
-	bodyInput := g4.String(body)
+	bodyInput := g4.String(truncateBody(body, g.maxBodyLength))
 	inputDraft := g4.AddProjectV2DraftIssueInput{
 		ProjectID: g4.ID(g.projectID),
 		Title:     g4.String(title),
 		Body:      &bodyInput,
 	}
 
-	if err := g.githubClient.Mutate(context.Background(), &mutationDraft, inputDraft, nil); err != nil {
-		return fmt.Errorf("failed to create draft issue: %w", err)
+	if err := g.mutate(g.context(), &mutationDraft, inputDraft); err != nil {
+		return nil, fmt.Errorf("failed to create draft issue: %w", err)
 	}
 
-	itemID := mutationDraft.AddProjectV2DraftIssue.ProjectItem.ID
+	item := mutationDraft.AddProjectV2DraftIssue.ProjectItem
+	itemID := item.ID
 	var mutationUpdate struct {
 		UpdateProjectV2ItemFieldValue struct {
 			ClientMutationID string
 		} `graphql:"updateProjectV2ItemFieldValue(input: $input)"`
 	}
 
-	fieldUpdates := []struct {
-		fieldID   g4.ID
-		optionID  g4.ID
-		fieldName string
-	}{
-		{k8sReleaseFieldID, k8sReleaseValueID, "K8s Release"},
-		{viewFieldID, viewValueID, "View"},
-		{statusFieldID, statusValueID, "Status"},
-		{boardFieldID, boardValueID, "Testgrid Board"},
-	}
-
-	for _, update := range fieldUpdates {
-		if update.fieldID != "" && update.optionID != "" {
+	for _, update := range resolved.updates(releaseField, boardField) {
+		if update.fieldID != "" && update.optionID != nil && update.optionID != "" {
 			optionIDStr := fmt.Sprintf("%s", update.optionID)
-			if err := g.githubClient.Mutate(context.Background(), &mutationUpdate, g4.UpdateProjectV2ItemFieldValueInput{
+			if err := g.mutate(g.context(), &mutationUpdate, g4.UpdateProjectV2ItemFieldValueInput{
 				ProjectID: g4.ID(g.projectID),
 				ItemID:    itemID,
 				FieldID:   update.fieldID,
 				Value:     g4.ProjectV2FieldValue{SingleSelectOptionID: (*g4.String)(&optionIDStr)},
-			}, nil); err != nil {
-				fmt.Printf("Warning: failed to update %s field: %v\n", update.fieldName, err)
+			}); err != nil {
+				slog.Warn("failed to update field", "field", update.name, "error", err)
 			}
 		}
 	}
-	return nil
+	if text := g.releaseTextOf(dashboard); resolved.releaseText != nil && !resolved.skipped[resolved.releaseText] && text != "" {
+		if err := g.mutate(g.context(), &mutationUpdate, g4.UpdateProjectV2ItemFieldValueInput{
+			ProjectID: g4.ID(g.projectID),
+			ItemID:    itemID,
+			FieldID:   resolved.releaseText,
+			Value:     g4.ProjectV2FieldValue{Text: g4.NewString(g4.String(text))},
+		}); err != nil {
+			slog.Warn("failed to update field", "field", "K8s Release", "error", err)
+		}
+	}
+	for _, update := range resolved.dateUpdates(request.FirstSeen, time.Now()) {
+		if err := g.mutate(g.context(), &mutationUpdate, g4.UpdateProjectV2ItemFieldValueInput{
+			ProjectID: g4.ID(g.projectID),
+			ItemID:    itemID,
+			FieldID:   update.fieldID,
+			Value:     g4.ProjectV2FieldValue{Date: g4.NewDate(g4.Date{Time: update.date})},
+		}); err != nil {
+			slog.Warn("failed to update field", "field", update.name, "error", err)
+		}
+	}
+	return newDraftIssue(itemID, item.Project.URL, item.DatabaseID), nil
 }
 
-// extractVersion extracts a version string from text (e.g., "v1.32" -> "1.32", "1.30" -> "1.30")
-func extractVersion(text string) string {
-	versionPattern := regexp.MustCompile(`v?(\d+)\.(\d+)`)
-	if matches := versionPattern.FindStringSubmatch(text); len(matches) >= 3 {
-		return fmt.Sprintf("%s.%s", matches[1], matches[2])
+// CreateDraftIssues creates the draft issues in order, the project fields are
+// queried once for the whole batch. A failed draft does not stop the batch: it
+// is nil in the returned drafts, at the index of its request, and its error is
+// joined in the returned error.
+func (g *ProjectManager) CreateDraftIssues(requests []DraftIssueRequest) ([]*DraftIssue, error) {
+	drafts := make([]*DraftIssue, len(requests))
+	var errs []error
+	for i, request := range requests {
+		draft, err := g.createDraftIssue(request)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", request.Title, err))
+			continue
+		}
+		drafts[i] = draft
 	}
-	return ""
+	return drafts, errors.Join(errs...)
 }
 
-// compareVersions compares two version strings (e.g., "1.30", "1.31")
-// Returns: 1 if v1 > v2, -1 if v1 < v2, 0 if equal
-func compareVersions(v1, v2 string) int {
-	parts1 := strings.Split(v1, ".")
-	parts2 := strings.Split(v2, ".")
+// newDraftIssue returns the created draft issue, linking the item pane of the project.
+func newDraftIssue(itemID g4.ID, projectURL string, databaseID int) *DraftIssue {
+	issue := &DraftIssue{URL: projectURL}
+	issue.ItemID, _ = itemID.(string)
+	if projectURL != "" && databaseID != 0 {
+		issue.URL = fmt.Sprintf("%s?pane=issue&itemId=%d", projectURL, databaseID)
+	}
+	return issue
+}
 
-	maxLen := len(parts1)
-	if len(parts2) > maxLen {
-		maxLen = len(parts2)
+// ValidateBoard verifies the board is one of the options of the Testgrid Board field.
+func (g *ProjectManager) ValidateBoard(board string) error {
+	fields, err := g.GetProjectFields()
+	if err != nil {
+		return fmt.Errorf("failed to get project fields: %w", err)
 	}
 
-	for i := 0; i < maxLen; i++ {
-		var num1, num2 int
-		if i < len(parts1) {
-			num1, _ = strconv.Atoi(parts1[i])
+	for _, field := range fields {
+		if !g.matchesField(FieldBoard, field) {
+			continue
 		}
-		if i < len(parts2) {
-			num2, _ = strconv.Atoi(parts2[i])
+		options := optionNames(field.Options)
+		for _, optName := range options {
+			if strings.EqualFold(optName, board) {
+				return nil
+			}
+		}
+		return fmt.Errorf("%w: unknown board %q, valid values are: %s", ErrFieldNotResolved, board, strings.Join(options, ", "))
+	}
+	return fmt.Errorf("%w: project has no Testgrid Board field to set board %q", ErrFieldNotResolved, board)
+}
+
+// wrapNotFound wraps GraphQL errors of missing nodes with ErrProjectNotFound.
+func wrapNotFound(err error) error {
+	if strings.Contains(err.Error(), notFoundMessage) {
+		return fmt.Errorf("%w: %w", ErrProjectNotFound, err)
+	}
+	return err
+}
+
+// partialDataFatal are contained in the errors that abort the whole query:
+// the HTTP status of an authentication or server failure, and the node that
+// does not exist.
+var partialDataFatal = []string{"non-200 OK status code", notFoundMessage}
+
+// isPartialData reports whether the query error is a GraphQL error returned
+// alongside data, e.g. the fields of a single path not resolved, rather than a
+// transport, authentication, rate limit or not found error. The errors type of
+// the GraphQL client is unexported, so the fatal errors are told apart by
+// their type and message.
+func isPartialData(err error) bool {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) || isRateLimited(err) {
+		return false
+	}
+	for _, message := range partialDataFatal {
+		if strings.Contains(err.Error(), message) {
+			return false
 		}
+	}
+	return true
+}
 
-		if num1 > num2 {
-			return 1
+// matchBoardOption returns the board option ID matching the board, an exact
+// match is preferred over an option name contained in the board, e.g.
+// "master-blocking" in the "sig-release-master-blocking" dashboard. Among the
+// contained names the longest wins, "master-blocking" over "blocking", and
+// the options are matched by sorted name, so the same option is picked on
+// every run.
+func matchBoardOption(options map[string]interface{}, board string) g4.ID {
+	board = strings.ToLower(board)
+	names := optionNames(options)
+	for _, optName := range names {
+		if strings.ToLower(optName) == board {
+			return options[optName]
 		}
-		if num1 < num2 {
-			return -1
+	}
+	var match string
+	for _, optName := range names {
+		if strings.Contains(board, strings.ToLower(optName)) && len(optName) > len(match) {
+			match = optName
 		}
 	}
-
-	return 0
+	if match == "" {
+		return nil
+	}
+	return options[match]
 }
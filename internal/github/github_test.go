@@ -0,0 +1,600 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	g4 "github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsValidProjectID(t *testing.T) {
+	tests := []struct {
+		name      string
+		projectID string
+		valid     bool
+	}{
+		{name: "default project id", projectID: PROJECT_ID, valid: true},
+		{name: "node id with dash", projectID: "PVT_kwDO-AM_34M", valid: true},
+		{name: "missing prefix", projectID: "kwDOAM_34M4AAThW"},
+		{name: "wrong prefix", projectID: "PVTI_kwDOAM_34M4AAThW"},
+		{name: "only prefix", projectID: "PVT_"},
+		{name: "invalid characters", projectID: "PVT_kwDO AM/34"},
+		{name: "empty", projectID: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.valid, isValidProjectID(tt.projectID))
+		})
+	}
+}
+
+func TestNewProjectManagerWithoutToken(t *testing.T) {
+	_, err := NewProjectManager(context.Background(), "", Options{})
+	assert.ErrorIs(t, err, ErrTokenMissing)
+}
+
+func TestWrapNotFound(t *testing.T) {
+	err := wrapNotFound(errors.New("Could not resolve to a node with the global id of 'PVT_x'"))
+	assert.ErrorIs(t, err, ErrProjectNotFound)
+	assert.NotErrorIs(t, wrapNotFound(errors.New("bad credentials")), ErrProjectNotFound)
+}
+
+func TestParseProjectNumber(t *testing.T) {
+	tests := []struct {
+		name      string
+		projectID string
+		number    int
+		numeric   bool
+	}{
+		{name: "project number", projectID: "123", number: 123, numeric: true},
+		{name: "zero is not a project", projectID: "0"},
+		{name: "negative number", projectID: "-4"},
+		{name: "node id", projectID: PROJECT_ID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			number, numeric := parseProjectNumber(tt.projectID)
+			assert.Equal(t, tt.numeric, numeric)
+			assert.Equal(t, tt.number, number)
+		})
+	}
+}
+
+func TestNewProjectManager(t *testing.T) {
+	tests := []struct {
+		name      string
+		projectID string
+		expected  string
+		wantErr   bool
+	}{
+		{name: "defaults to the release board", projectID: "", expected: PROJECT_ID},
+		{name: "trims the node id", projectID: " PVT_kwDOAM_34M4AAThW ", expected: PROJECT_ID},
+		{name: "mistyped node id", projectID: "PTV_kwDOAM_34M4AAThW", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gh, err := NewProjectManager(context.Background(), "token", Options{ProjectID: tt.projectID})
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "invalid project ID")
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, gh.(*ProjectManager).projectID)
+		})
+	}
+}
+
+func TestMatchBoardOption(t *testing.T) {
+	options := map[string]interface{}{
+		"master-blocking":  "blocking-id",
+		"master-informing": "informing-id",
+	}
+	tests := []struct {
+		name     string
+		board    string
+		expected interface{}
+	}{
+		{name: "exact board name", board: "master-informing", expected: "informing-id"},
+		{name: "case insensitive", board: "Master-Blocking", expected: "blocking-id"},
+		{name: "inferred from dashboard", board: "sig-release-master-blocking", expected: "blocking-id"},
+		{name: "inferred from informing dashboard", board: "sig-release-master-informing", expected: "informing-id"},
+		{name: "unknown board", board: "release-1.32-blocking"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, matchBoardOption(options, tt.board))
+		})
+	}
+}
+
+func TestMatchBoardOptionStable(t *testing.T) {
+	// both options are contained in the dashboard, the longest one wins on
+	// every run whatever the order of the map
+	options := map[string]interface{}{
+		"blocking":        "blocking-id",
+		"master-blocking": "master-blocking-id",
+	}
+	for range 10 {
+		assert.Equal(t, "master-blocking-id", matchBoardOption(options, "sig-release-master-blocking"))
+	}
+}
+
+func TestMatchesField(t *testing.T) {
+	gh := &ProjectManager{fieldNames: map[string]string{FieldRelease: "Target Version", FieldStatus: "PVTSSF_status2"}}
+	tests := []struct {
+		name     string
+		role     string
+		field    string
+		id       string
+		expected bool
+	}{
+		{name: "mapped name", role: FieldRelease, field: "Target Version", expected: true},
+		{name: "mapped name is case insensitive", role: FieldRelease, field: "target version", expected: true},
+		{name: "mapped role ignores the default name", role: FieldRelease, field: "K8s Release"},
+		{name: "unmapped role uses the default name", role: FieldBoard, field: "Testgrid Board", expected: true},
+		{name: "unmapped role without match", role: FieldView, field: "Priority"},
+		{name: "mapped ID", role: FieldStatus, field: "Status", id: "PVTSSF_status2", expected: true},
+		{name: "mapped ID ignores the same name", role: FieldStatus, field: "Status", id: "PVTSSF_status1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, gh.matchesField(tt.role, ProjectFieldInfo{ID: tt.id, Name: g4.String(tt.field)}))
+		})
+	}
+}
+
+func TestDuplicateFieldNames(t *testing.T) {
+	fields := []ProjectFieldInfo{
+		{ID: "PVTSSF_status1", Name: "Status"},
+		{ID: "PVTSSF_board", Name: "Testgrid Board"},
+		{ID: "PVTSSF_status2", Name: "status "},
+	}
+	assert.Equal(t, []string{"status"}, duplicateFieldNames(fields))
+	assert.Empty(t, duplicateFieldNames(fields[:2]))
+}
+
+func TestNewProjectManagerFieldNames(t *testing.T) {
+	_, err := NewProjectManager(context.Background(), "token", Options{FieldNames: map[string]string{"priority": "Priority"}})
+	assert.ErrorContains(t, err, `unknown field role "priority"`)
+
+	_, err = NewProjectManager(context.Background(), "token", Options{FieldNames: map[string]string{FieldStatus: " "}})
+	assert.ErrorContains(t, err, "empty field name")
+
+	gh, err := NewProjectManager(context.Background(), "token", Options{FieldNames: map[string]string{FieldStatus: "State"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "State", gh.(*ProjectManager).fieldNames[FieldStatus])
+}
+
+func TestFieldsPageSize(t *testing.T) {
+	for _, size := range []int{-1, MaxFieldsPageSize + 1} {
+		_, err := NewProjectManager(context.Background(), "token", Options{FieldsPageSize: size})
+		assert.ErrorContains(t, err, "fields page size must be between 1 and 100")
+	}
+
+	tests := []struct {
+		name     string
+		size     int
+		expected int
+	}{
+		{name: "default", expected: DefaultFieldsPageSize},
+		{name: "small board", size: 10, expected: 10},
+		{name: "max", size: MaxFieldsPageSize, expected: MaxFieldsPageSize},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var request struct {
+					Query     string `json:"query"`
+					Variables struct {
+						FieldsPageSize int `json:"fieldsPageSize"`
+					} `json:"variables"`
+				}
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+				assert.Contains(t, request.Query, "fields(first: $fieldsPageSize)")
+				sent = request.Variables.FieldsPageSize
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(projectFieldsResponse)) // nolint
+			}))
+			defer server.Close()
+
+			gh := &ProjectManager{projectID: PROJECT_ID, githubClient: g4.NewEnterpriseClient(server.URL, server.Client()), fieldsPageSize: tt.size}
+			_, err := gh.GetProjectFields()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, sent)
+		})
+	}
+}
+
+func TestNewDraftIssue(t *testing.T) {
+	const projectURL = "https://github.com/orgs/kubernetes/projects/68"
+	tests := []struct {
+		name       string
+		itemID     g4.ID
+		projectURL string
+		databaseID int
+		expected   *DraftIssue
+	}{
+		{
+			name: "item pane of the project", itemID: "PVTI_lADOAM_34M4AAThWzgd", projectURL: projectURL, databaseID: 12345,
+			expected: &DraftIssue{ItemID: "PVTI_lADOAM_34M4AAThWzgd", URL: projectURL + "?pane=issue&itemId=12345"},
+		},
+		{
+			name: "project without item number", itemID: "PVTI_lADOAM_34M4AAThWzgd", projectURL: projectURL,
+			expected: &DraftIssue{ItemID: "PVTI_lADOAM_34M4AAThWzgd", URL: projectURL},
+		},
+		{name: "empty response", expected: &DraftIssue{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, newDraftIssue(tt.itemID, tt.projectURL, tt.databaseID))
+		})
+	}
+}
+
+func TestStats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"node":{"fields":{"nodes":[{"__typename":"ProjectV2SingleSelectField",` + // nolint
+			`"id":"board-field","name":"Testgrid Board","options":[{"id":"blocking-id","name":"master-blocking"}]}]}}}}`))
+	}))
+	defer server.Close()
+
+	gh := &ProjectManager{projectID: PROJECT_ID, githubClient: g4.NewEnterpriseClient(server.URL, server.Client())}
+	for range 3 {
+		fields, err := gh.GetProjectFields()
+		assert.NoError(t, err)
+		assert.Len(t, fields, 1)
+	}
+	assert.NoError(t, gh.ValidateBoard("master-blocking"))
+	assert.Equal(t, Stats{Queries: 1, FieldCacheHits: 3, FieldCacheMisses: 1}, gh.Stats())
+}
+
+func TestStatsQueryCost(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct{ Query string }
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		queries = append(queries, body.Query)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"rateLimit":{"cost":3,"remaining":4990},"node":{"fields":{"nodes":[]}}}}`)) // nolint
+	}))
+	defer server.Close()
+
+	gh := &ProjectManager{projectID: PROJECT_ID, githubClient: g4.NewEnterpriseClient(server.URL, server.Client())}
+	for range 2 {
+		_, err := gh.queryProjectFields()
+		assert.NoError(t, err)
+	}
+	assert.Len(t, queries, 2)
+	assert.Contains(t, queries[0], "rateLimit{cost,remaining}")
+	assert.Equal(t, 6, gh.Stats().QueryCost)
+	assert.Equal(t, 4990, gh.Stats().RateLimitRemaining)
+}
+
+func TestStatsFailedQueryIsNotCached(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	gh := &ProjectManager{projectID: PROJECT_ID, githubClient: g4.NewEnterpriseClient(server.URL, server.Client())}
+	for range 2 {
+		_, err := gh.GetProjectFields()
+		assert.Error(t, err)
+	}
+	assert.Equal(t, Stats{Queries: 2, FieldCacheMisses: 2}, gh.Stats())
+}
+
+func TestGetProjectFieldsPartialData(t *testing.T) {
+	const fieldNodes = `{"data":{"node":{"fields":{"nodes":[{"__typename":"ProjectV2Field","id":"notes-field","name":"Notes","dataType":"TEXT"}]}}},`
+	tests := []struct {
+		name       string
+		response   string
+		wantFields int
+		wantErr    error
+	}{
+		{
+			name:       "errors alongside fields",
+			response:   fieldNodes + `"errors":[{"message":"Field 'options' is not accessible"}]}`,
+			wantFields: 1,
+		},
+		{
+			name:     "errors without fields",
+			response: `{"data":{"node":{"fields":{"nodes":[]}}},"errors":[{"message":"Field 'options' is not accessible"}]}`,
+		},
+		{
+			name:     "not found is fatal",
+			response: fieldNodes + `"errors":[{"message":"Could not resolve to a node with the global id of 'PVT_x'"}]}`,
+			wantErr:  ErrProjectNotFound,
+		},
+		{
+			name:     "rate limit is fatal",
+			response: fieldNodes + `"errors":[{"type":"RATE_LIMITED","message":"API rate limit exceeded for user ID 1."}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(tt.response)) // nolint
+			}))
+			defer server.Close()
+
+			gh := &ProjectManager{projectID: PROJECT_ID, githubClient: g4.NewEnterpriseClient(server.URL, server.Client())}
+			fields, err := gh.GetProjectFields()
+			if tt.wantFields == 0 {
+				assert.Error(t, err)
+				if tt.wantErr != nil {
+					assert.ErrorIs(t, err, tt.wantErr)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, fields, tt.wantFields)
+		})
+	}
+}
+
+func TestGetProjectFieldsEmptyRetries(t *testing.T) {
+	tests := []struct {
+		name        string
+		retries     int
+		empty       int
+		wantErr     error
+		wantQueries int
+	}{
+		{name: "retried until fields", retries: 1, empty: 1, wantQueries: 2},
+		{name: "still empty", retries: 2, empty: 5, wantErr: ErrNoProjectFields, wantQueries: 3},
+		{name: "no retry", empty: 1, wantErr: ErrNoProjectFields, wantQueries: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				queries++
+				w.Header().Set("Content-Type", "application/json")
+				if queries <= tt.empty {
+					w.Write([]byte(`{"data":{"node":{"fields":{"nodes":[]}}}}`)) // nolint
+					return
+				}
+				w.Write([]byte(projectFieldsResponse)) // nolint
+			}))
+			defer server.Close()
+
+			gh := &ProjectManager{
+				projectID:          PROJECT_ID,
+				githubClient:       g4.NewEnterpriseClient(server.URL, server.Client()),
+				emptyFieldsRetries: tt.retries,
+			}
+			fields, err := gh.GetProjectFields()
+			assert.Equal(t, tt.wantQueries, queries)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotEmpty(t, fields)
+		})
+	}
+}
+
+func TestIsPartialData(t *testing.T) {
+	assert.True(t, isPartialData(errors.New("Field 'options' is not accessible")))
+	assert.False(t, isPartialData(errors.New(`non-200 OK status code: 401 Unauthorized body: "Bad credentials"`)))
+	assert.False(t, isPartialData(&url.Error{Op: "Post", URL: "https://api.github.com/graphql", Err: errors.New("connection refused")}))
+	assert.False(t, isPartialData(context.DeadlineExceeded))
+}
+
+func TestCreateDraftIssues(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(string(body), "broken test"):
+			http.Error(w, "unavailable", http.StatusBadGateway)
+		case strings.Contains(string(body), "addProjectV2DraftIssue"):
+			w.Write([]byte(`{"data":{"addProjectV2DraftIssue":{"projectItem":{"id":"PVTI_item","databaseId":1,` + // nolint
+				`"project":{"url":"https://github.com/orgs/kubernetes/projects/68"}}}}}`))
+		default:
+			w.Write([]byte(projectFieldsResponse)) // nolint
+		}
+	}))
+	defer server.Close()
+
+	gh := &ProjectManager{projectID: PROJECT_ID, githubClient: g4.NewEnterpriseClient(server.URL, server.Client())}
+	drafts, err := gh.CreateDraftIssues([]DraftIssueRequest{
+		{Title: "[Failing Test] first test", Body: "body"},
+		{Title: "[Failing Test] broken test", Body: "body"},
+		{Title: "[Failing Test] last test", Body: "body"},
+	})
+	assert.ErrorContains(t, err, "broken test")
+	assert.Len(t, drafts, 3)
+	assert.Equal(t, "PVTI_item", drafts[0].ItemID)
+	assert.Nil(t, drafts[1])
+	assert.Equal(t, "PVTI_item", drafts[2].ItemID)
+	assert.Equal(t, 1, gh.Stats().FieldCacheMisses)
+}
+
+func TestGitHubTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// hang until the test ends
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	gh := &ProjectManager{
+		projectID:    PROJECT_ID,
+		githubClient: g4.NewEnterpriseClient(server.URL, server.Client()),
+		timeout:      50 * time.Millisecond,
+	}
+	_, err := gh.GetProjectFields()
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, "GitHub did not answer within 50ms")
+
+	_, err = gh.CreateDraftIssue("title", "body", "master-blocking")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestCanceledContext(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	gh := &ProjectManager{
+		projectID:    PROJECT_ID,
+		githubClient: g4.NewEnterpriseClient(server.URL, server.Client()),
+		ctx:          ctx,
+	}
+	// the call in flight is aborted
+	time.AfterFunc(20*time.Millisecond, cancel)
+	_, err := gh.GetProjectFields()
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// and the next ones fail right away
+	start := time.Now()
+	_, err = gh.CreateDraftIssue("title", "body", "master-blocking")
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+// optionUpdate is a single select field set by an updateProjectV2ItemFieldValue mutation
+type optionUpdate struct {
+	ItemID   string
+	FieldID  string
+	OptionID string
+}
+
+// newProjectServer serves the project fields of the fixture of testdata,
+// creates the draft issues and records the single select fields set on them.
+func newProjectServer(t *testing.T, fixture string) (*httptest.Server, *[]optionUpdate) {
+	t.Helper()
+	fields, err := os.ReadFile(filepath.Join("testdata", fixture))
+	assert.NoError(t, err)
+	var updates []optionUpdate
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(string(data), "addProjectV2DraftIssue"):
+			w.Write([]byte(`{"data":{"addProjectV2DraftIssue":{"projectItem":{"id":"PVTI_item","databaseId":1,` + // nolint
+				`"project":{"url":"https://github.com/orgs/kubernetes/projects/68"}}}}}`))
+		case strings.Contains(string(data), "updateProjectV2ItemFieldValue"):
+			var request struct {
+				Variables struct {
+					Input struct {
+						ProjectID string `json:"projectId"`
+						ItemID    string `json:"itemId"`
+						FieldID   string `json:"fieldId"`
+						Value     struct {
+							SingleSelectOptionID string `json:"singleSelectOptionId"`
+						} `json:"value"`
+					} `json:"input"`
+				} `json:"variables"`
+			}
+			assert.NoError(t, json.Unmarshal(data, &request))
+			assert.Equal(t, PROJECT_ID, request.Variables.Input.ProjectID)
+			input := request.Variables.Input
+			updates = append(updates, optionUpdate{ItemID: input.ItemID, FieldID: input.FieldID, OptionID: input.Value.SingleSelectOptionID})
+			w.Write([]byte(`{"data":{"updateProjectV2ItemFieldValue":{"clientMutationId":""}}}`)) // nolint
+		default:
+			w.Write(fields) // nolint
+		}
+	}))
+	return server, &updates
+}
+
+func TestCreateDraftIssueFieldMapping(t *testing.T) {
+	tests := []struct {
+		name     string
+		board    string
+		release  string
+		expected []optionUpdate
+	}{
+		{
+			name:  "master-blocking on the latest release",
+			board: "sig-release-master-blocking",
+			expected: []optionUpdate{
+				{"PVTI_item", "PVTSSF_release", "release-v133"},
+				{"PVTI_item", "PVTSSF_view", "view-tracking"},
+				{"PVTI_item", "PVTSSF_status", "status-drafting"},
+				{"PVTI_item", "PVTSSF_board", "board-blocking"},
+			},
+		},
+		{
+			name:  "master-informing",
+			board: "master-informing",
+			expected: []optionUpdate{
+				{"PVTI_item", "PVTSSF_release", "release-v133"},
+				{"PVTI_item", "PVTSSF_view", "view-tracking"},
+				{"PVTI_item", "PVTSSF_status", "status-drafting"},
+				{"PVTI_item", "PVTSSF_board", "board-informing"},
+			},
+		},
+		{
+			name:    "configured release",
+			board:   "sig-release-master-blocking",
+			release: "1.31",
+			expected: []optionUpdate{
+				{"PVTI_item", "PVTSSF_release", "release-v131"},
+				{"PVTI_item", "PVTSSF_view", "view-tracking"},
+				{"PVTI_item", "PVTSSF_status", "status-drafting"},
+				{"PVTI_item", "PVTSSF_board", "board-blocking"},
+			},
+		},
+		{
+			// no option is matched, the fields are left at the board defaults
+			name:  "unknown board and release",
+			board: "sig-node-release-blocking", release: "1.35",
+			expected: []optionUpdate{
+				{"PVTI_item", "PVTSSF_view", "view-tracking"},
+				{"PVTI_item", "PVTSSF_status", "status-drafting"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, updates := newProjectServer(t, "project_fields.json")
+			defer server.Close()
+
+			gh := &ProjectManager{projectID: PROJECT_ID, release: tt.release, githubClient: g4.NewEnterpriseClient(server.URL, server.Client())}
+			draft, err := gh.CreateDraftIssue("[Failing Test] Overall", "body", tt.board)
+			assert.NoError(t, err)
+			assert.Equal(t, "PVTI_item", draft.ItemID)
+			assert.Equal(t, tt.expected, *updates)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	g4 "github.com/shurcooL/githubv4"
+	"sigs.k8s.io/signalhound/internal/version"
+)
+
+// ReleaseAuto resolves the current release cycle from the latest stable release.
+const ReleaseAuto = "auto"
+
+// StableReleaseURL serves the latest stable Kubernetes release, e.g. v1.34.1.
+var StableReleaseURL = "https://dl.k8s.io/release/stable.txt"
+
+// ResolveRelease returns the release cycle, e.g. "1.35", set on the K8s Release
+// field of draft issues. The value is either a version like v1.35, or
+// ReleaseAuto for the minor release following the latest stable release. An
+// empty value returns an empty release, the latest release option of the
+// field is then picked.
+func ResolveRelease(ctx context.Context, client *http.Client, value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", nil
+	}
+	if value != ReleaseAuto {
+		release := version.Extract(value)
+		if release == "" {
+			return "", fmt.Errorf("invalid release %q, expected a version like v1.35 or %s", value, ReleaseAuto)
+		}
+		return release, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, StableReleaseURL, nil)
+	if err != nil {
+		return "", err
+	}
+	response, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch the latest stable release: %w", err)
+	}
+	defer response.Body.Close() // nolint:errcheck
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to fetch the latest stable release: %s returned %s", StableReleaseURL, response.Status)
+	}
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the latest stable release: %w", err)
+	}
+	return nextRelease(string(data))
+}
+
+// nextRelease returns the minor release following a stable release, e.g.
+// "1.35" for v1.34.1, which is the release cycle in progress.
+func nextRelease(stable string) (string, error) {
+	release := version.Extract(stable)
+	major, minor, _ := strings.Cut(release, ".")
+	number, err := strconv.Atoi(minor)
+	if release == "" || err != nil {
+		return "", fmt.Errorf("unexpected stable release %q", strings.TrimSpace(stable))
+	}
+	return fmt.Sprintf("%s.%d", major, number+1), nil
+}
+
+// releaseOption returns the option ID of the release field, matching the
+// release when set, otherwise the latest version of the options.
+func releaseOption(options map[string]interface{}, release string) g4.ID {
+	if release != "" {
+		for _, optName := range optionNames(options) {
+			if version.Extract(optName) == release {
+				return options[optName]
+			}
+		}
+		return nil
+	}
+
+	var latestVersion string
+	var latestVersionID g4.ID
+	for _, optName := range optionNames(options) {
+		// extract version number from option name (e.g., "v1.32" -> "1.32")
+		if optVersion := version.Extract(optName); optVersion != "" {
+			if latestVersion == "" || version.Compare(optVersion, latestVersion) > 0 {
+				latestVersion = optVersion
+				latestVersionID = options[optName]
+			}
+		}
+	}
+	return latestVersionID
+}
@@ -0,0 +1,81 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveRelease(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("v1.34.1\n")) // nolint
+	}))
+	defer server.Close()
+	stableURL := StableReleaseURL
+	StableReleaseURL = server.URL
+	defer func() { StableReleaseURL = stableURL }()
+
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+		wantErr  bool
+	}{
+		{name: "disabled"},
+		{name: "version with prefix", value: "v1.35", expected: "1.35"},
+		{name: "version without prefix", value: " 1.36 ", expected: "1.36"},
+		{name: "next release of the stable one", value: ReleaseAuto, expected: "1.35"},
+		{name: "invalid version", value: "next", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			release, err := ResolveRelease(context.Background(), server.Client(), tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, release)
+		})
+	}
+}
+
+func TestResolveReleaseUnavailable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	stableURL := StableReleaseURL
+	StableReleaseURL = server.URL
+	defer func() { StableReleaseURL = stableURL }()
+
+	_, err := ResolveRelease(context.Background(), server.Client(), ReleaseAuto)
+	assert.ErrorContains(t, err, "503")
+}
+
+func TestReleaseOption(t *testing.T) {
+	options := map[string]interface{}{
+		"v1.33": "v133-id",
+		"v1.34": "v134-id",
+		"none":  "none-id",
+	}
+	tests := []struct {
+		name     string
+		release  string
+		expected interface{}
+	}{
+		{name: "latest option", expected: "v134-id"},
+		{name: "configured release", release: "1.33", expected: "v133-id"},
+		{name: "release not on the board yet", release: "1.35"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, releaseOption(options, tt.release))
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// ErrMissingScopes is returned when the GitHub token lacks a scope required to create draft issues.
+var ErrMissingScopes = errors.New("GitHub token missing required scopes")
+
+// RequiredScopes are the classic token scopes needed to create and update project items.
+var RequiredScopes = []string{"project"}
+
+// APIURL is the GitHub REST endpoint answering with the scopes of the token.
+var APIURL = "https://api.github.com/"
+
+// CheckTokenScopes verifies the token has the RequiredScopes, read from the
+// X-OAuth-Scopes header of a REST call. Fine-grained tokens and GitHub Apps do
+// not report scopes, their permissions can not be checked and no error is
+// returned.
+func CheckTokenScopes(ctx context.Context, client *http.Client, token string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, APIURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	response, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to check the GitHub token scopes: %w", err)
+	}
+	defer response.Body.Close() // nolint:errcheck
+	if response.StatusCode == http.StatusUnauthorized {
+		return fmt.Errorf("GitHub token rejected: %s", response.Status)
+	}
+
+	header, ok := response.Header[http.CanonicalHeaderKey("X-OAuth-Scopes")]
+	if !ok {
+		return nil
+	}
+	if missing := missingScopes(strings.Join(header, ","), RequiredScopes); len(missing) > 0 {
+		return fmt.Errorf("%w: %s, add them to the token to create draft issues", ErrMissingScopes, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// missingScopes returns the required scopes absent from the comma separated
+// scopes of a X-OAuth-Scopes header.
+func missingScopes(header string, required []string) (missing []string) {
+	var scopes []string
+	for _, scope := range strings.Split(header, ",") {
+		scopes = append(scopes, strings.TrimSpace(scope))
+	}
+	for _, scope := range required {
+		if !slices.Contains(scopes, scope) {
+			missing = append(missing, scope)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,65 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMissingScopes(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		expected []string
+	}{
+		{name: "project scope", header: "repo, project, read:org"},
+		{name: "read only project scope", header: "repo, read:project", expected: []string{"project"}},
+		{name: "no scopes", header: "", expected: []string{"project"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, missingScopes(tt.header, RequiredScopes))
+		})
+	}
+}
+
+func TestCheckTokenScopes(t *testing.T) {
+	tests := []struct {
+		name    string
+		scopes  []string
+		status  int
+		wantErr string
+	}{
+		{name: "required scopes", scopes: []string{"repo, project"}, status: http.StatusOK},
+		{name: "missing project scope", scopes: []string{"repo, read:project"}, status: http.StatusOK, wantErr: ErrMissingScopes.Error()},
+		{name: "fine-grained token without scopes", status: http.StatusOK},
+		{name: "rejected token", status: http.StatusUnauthorized, wantErr: "401"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+				for _, scopes := range tt.scopes {
+					w.Header().Add("X-OAuth-Scopes", scopes)
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+			apiURL := APIURL
+			APIURL = server.URL
+			defer func() { APIURL = apiURL }()
+
+			err := CheckTokenScopes(context.Background(), server.Client(), "token")
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package github
+
+import (
+	"context"
+	"strings"
+
+	g4 "github.com/shurcooL/githubv4"
+	"golang.org/x/oauth2"
+)
+
+// rateLimitMessage is contained in the errors of the primary and secondary
+// GitHub rate limits, lowercased.
+const rateLimitMessage = "rate limit"
+
+// newClient returns a GraphQL client authenticated with the token.
+func newClient(ctx context.Context, token string) *g4.Client {
+	return g4.NewClient(oauth2.NewClient(
+		ctx, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token}),
+	))
+}
+
+// isRateLimited reports whether the GitHub call failed on a rate limit.
+func isRateLimited(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), rateLimitMessage)
+}
+
+// activeClient returns the client of the token in use.
+func (g *ProjectManager) activeClient() *g4.Client {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.githubClient
+}
+
+// rotate switches to the client of the next token after the failed client and
+// reports whether another token is available. A rotation made by a concurrent
+// call in the meantime is kept.
+func (g *ProjectManager) rotate(failed *g4.Client) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if len(g.clients) < 2 {
+		return false
+	}
+	if g.githubClient == failed {
+		g.active = (g.active + 1) % len(g.clients)
+		g.githubClient = g.clients[g.active]
+		g.stats.TokenRotations++
+	}
+	return true
+}
+
+// withFailover runs the call with the active client, moving to the next token
+// when it is rate limited, until every token has been tried once.
+func (g *ProjectManager) withFailover(call func(client *g4.Client) error) error {
+	attempts := max(len(g.clients), 1)
+	var err error
+	for range attempts {
+		client := g.activeClient()
+		if err = call(client); err == nil || !isRateLimited(err) || !g.rotate(client) {
+			return err
+		}
+	}
+	return err
+}
@@ -0,0 +1,67 @@
+package github
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	g4 "github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+)
+
+func rateLimitedServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"errors":[{"type":"RATE_LIMITED","message":"API rate limit exceeded for user ID 1."}]}`)) // nolint
+	}))
+}
+
+func TestIsRateLimited(t *testing.T) {
+	assert.True(t, isRateLimited(errors.New("API rate limit exceeded for user ID 1.")))
+	assert.True(t, isRateLimited(errors.New("You have exceeded a secondary rate limit.")))
+	assert.False(t, isRateLimited(errors.New("Could not resolve to a node with the global id of 'PVT_x'")))
+}
+
+func TestTokenRotation(t *testing.T) {
+	limited := rateLimitedServer()
+	defer limited.Close()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(projectFieldsResponse)) // nolint
+	}))
+	defer server.Close()
+
+	limitedClient := g4.NewEnterpriseClient(limited.URL, limited.Client())
+	clients := []*g4.Client{limitedClient, g4.NewEnterpriseClient(server.URL, server.Client())}
+	gh := &ProjectManager{projectID: PROJECT_ID, githubClient: limitedClient, clients: clients}
+
+	_, err := gh.GetProjectFields()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, gh.active)
+	assert.Equal(t, Stats{Queries: 2, FieldCacheMisses: 1, TokenRotations: 1}, gh.Stats())
+}
+
+func TestTokenRotationExhausted(t *testing.T) {
+	first, second := rateLimitedServer(), rateLimitedServer()
+	defer first.Close()
+	defer second.Close()
+
+	firstClient := g4.NewEnterpriseClient(first.URL, first.Client())
+	clients := []*g4.Client{firstClient, g4.NewEnterpriseClient(second.URL, second.Client())}
+	gh := &ProjectManager{projectID: PROJECT_ID, githubClient: firstClient, clients: clients}
+
+	_, err := gh.GetProjectFields()
+	assert.ErrorContains(t, err, "rate limit")
+	assert.Equal(t, 2, gh.Stats().Queries)
+}
+
+func TestSingleTokenIsNotRotated(t *testing.T) {
+	limited := rateLimitedServer()
+	defer limited.Close()
+
+	gh := &ProjectManager{projectID: PROJECT_ID, githubClient: g4.NewEnterpriseClient(limited.URL, limited.Client())}
+	_, err := gh.GetProjectFields()
+	assert.ErrorContains(t, err, "rate limit")
+	assert.Equal(t, Stats{Queries: 1, FieldCacheMisses: 1}, gh.Stats())
+}
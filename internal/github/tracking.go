@@ -0,0 +1,148 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	g4 "github.com/shurcooL/githubv4"
+)
+
+// RollupMarker is the hidden marker of the rollup comment, so the same comment
+// of the tracking issue is found and edited on every run.
+const RollupMarker = "<!-- signalhound-rollup -->"
+
+// trackingIssuePattern matches a tracking issue reference, e.g. kubernetes/sig-release#2345
+var trackingIssuePattern = regexp.MustCompile(`^([A-Za-z0-9_.-]+)/([A-Za-z0-9_.-]+)#([0-9]+)$`)
+
+// TrackingIssue is the issue the rollup comment is posted on
+type TrackingIssue struct {
+	Owner  string
+	Repo   string
+	Number int
+}
+
+// String renders the issue reference, owner/repo#number.
+func (i TrackingIssue) String() string {
+	return fmt.Sprintf("%s/%s#%d", i.Owner, i.Repo, i.Number)
+}
+
+// ParseTrackingIssue parses an issue reference like kubernetes/sig-release#2345.
+func ParseTrackingIssue(ref string) (TrackingIssue, error) {
+	match := trackingIssuePattern.FindStringSubmatch(strings.TrimSpace(ref))
+	if match == nil {
+		return TrackingIssue{}, fmt.Errorf("invalid tracking issue %q, expected owner/repo#number", ref)
+	}
+	number, err := strconv.Atoi(match[3])
+	if err != nil || number == 0 {
+		return TrackingIssue{}, fmt.Errorf("invalid tracking issue %q, expected owner/repo#number", ref)
+	}
+	return TrackingIssue{Owner: match[1], Repo: match[2], Number: number}, nil
+}
+
+// UpsertRollupComment posts the body as a comment of the tracking issue, or
+// edits the comment posted by a previous run, the most recent comment of the
+// token user with the RollupMarker, by its node ID. The marker is prepended to
+// the body when missing, and the URL of the comment is returned.
+func (g *ProjectManager) UpsertRollupComment(issue TrackingIssue, body string) (string, error) {
+	if g.githubClient == nil {
+		return "", errors.New("github GraphQL client is nil")
+	}
+	if !strings.Contains(body, RollupMarker) {
+		body = RollupMarker + "\n" + body
+	}
+
+	issueID, comment, err := g.findRollupComment(issue)
+	if err != nil {
+		return "", err
+	}
+	if comment.ID != nil {
+		var mutationUpdate struct {
+			UpdateIssueComment struct {
+				IssueComment struct {
+					URL string
+				}
+			} `graphql:"updateIssueComment(input: $input)"`
+		}
+		if err := g.mutate(g.context(), &mutationUpdate, g4.UpdateIssueCommentInput{
+			ID:   comment.ID,
+			Body: g4.String(body),
+		}); err != nil {
+			return "", fmt.Errorf("failed to update the rollup comment of %s: %w", issue, err)
+		}
+		return mutationUpdate.UpdateIssueComment.IssueComment.URL, nil
+	}
+
+	var mutationAdd struct {
+		AddComment struct {
+			CommentEdge struct {
+				Node struct {
+					URL string
+				}
+			}
+		} `graphql:"addComment(input: $input)"`
+	}
+	if err := g.mutate(g.context(), &mutationAdd, g4.AddCommentInput{
+		SubjectID: issueID,
+		Body:      g4.String(body),
+	}); err != nil {
+		return "", fmt.Errorf("failed to comment on %s: %w", issue, err)
+	}
+	return mutationAdd.AddComment.CommentEdge.Node.URL, nil
+}
+
+// rollupComment is a comment of the tracking issue
+type rollupComment struct {
+	ID              g4.ID
+	URL             string
+	Body            string
+	ViewerDidAuthor bool
+}
+
+// findRollupComment returns the node ID of the tracking issue and its rollup
+// comment, with a nil ID when none was posted yet. The comments are paged from
+// the most recent one.
+func (g *ProjectManager) findRollupComment(issue TrackingIssue) (g4.ID, rollupComment, error) {
+	variables := map[string]interface{}{
+		"owner":  g4.String(issue.Owner),
+		"name":   g4.String(issue.Repo),
+		"number": g4.Int(issue.Number),
+		"cursor": (*g4.String)(nil),
+	}
+	for {
+		var query struct {
+			rateLimit
+			Repository struct {
+				Issue struct {
+					ID       g4.ID
+					Comments struct {
+						Nodes    []rollupComment
+						PageInfo struct {
+							HasPreviousPage bool
+							StartCursor     g4.String
+						}
+					} `graphql:"comments(last: 100, before: $cursor)"`
+				} `graphql:"issue(number: $number)"`
+			} `graphql:"repository(owner: $owner, name: $name)"`
+		}
+		if err := g.query(g.context(), &query, variables); err != nil {
+			if strings.Contains(err.Error(), notFoundMessage) {
+				return nil, rollupComment{}, fmt.Errorf("%w: --tracking-issue %s: %w", ErrTrackingIssueNotFound, issue, err)
+			}
+			return nil, rollupComment{}, fmt.Errorf("failed to query the tracking issue %s: %w", issue, err)
+		}
+		comments := query.Repository.Issue.Comments
+		for i := len(comments.Nodes) - 1; i >= 0; i-- {
+			if comment := comments.Nodes[i]; comment.ViewerDidAuthor && strings.Contains(comment.Body, RollupMarker) {
+				return query.Repository.Issue.ID, comment, nil
+			}
+		}
+		if !comments.PageInfo.HasPreviousPage {
+			return query.Repository.Issue.ID, rollupComment{}, nil
+		}
+		cursor := comments.PageInfo.StartCursor
+		variables["cursor"] = &cursor
+	}
+}
@@ -0,0 +1,101 @@
+package github
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	g4 "github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTrackingIssue(t *testing.T) {
+	issue, err := ParseTrackingIssue(" kubernetes/sig-release#2345 ")
+	assert.NoError(t, err)
+	assert.Equal(t, TrackingIssue{Owner: "kubernetes", Repo: "sig-release", Number: 2345}, issue)
+	assert.Equal(t, "kubernetes/sig-release#2345", issue.String())
+
+	for _, ref := range []string{"kubernetes/sig-release", "sig-release#2345", "kubernetes/sig-release#0", "https://github.com/kubernetes/sig-release/issues/2345"} {
+		_, err := ParseTrackingIssue(ref)
+		assert.ErrorContains(t, err, "expected owner/repo#number", ref)
+	}
+}
+
+func TestUpsertRollupComment(t *testing.T) {
+	const previous = `{"id":"comment-old","url":"https://github.com/kubernetes/sig-release/issues/1#issuecomment-1","body":"` + RollupMarker + `\nlast week","viewerDidAuthor":true}`
+	tests := []struct {
+		name         string
+		comments     string
+		wantMutation string
+		wantInput    map[string]interface{}
+		wantURL      string
+	}{
+		{
+			name:         "first run",
+			comments:     `{"id":"comment-1","url":"u1","body":"` + RollupMarker + `","viewerDidAuthor":false}`,
+			wantMutation: "addComment",
+			wantInput:    map[string]interface{}{"subjectId": "issue-id", "body": RollupMarker + "\n| report |"},
+			wantURL:      "https://github.com/kubernetes/sig-release/issues/1#issuecomment-2",
+		},
+		{
+			name:         "edits the previous comment",
+			comments:     previous + `,{"id":"comment-2","url":"u2","body":"lgtm","viewerDidAuthor":true}`,
+			wantMutation: "updateIssueComment",
+			wantInput:    map[string]interface{}{"id": "comment-old", "body": RollupMarker + "\n| report |"},
+			wantURL:      "https://github.com/kubernetes/sig-release/issues/1#issuecomment-1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mutation string
+			var input map[string]interface{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var request struct {
+					Query     string `json:"query"`
+					Variables struct {
+						Input map[string]interface{} `json:"input"`
+					} `json:"variables"`
+				}
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case strings.Contains(request.Query, "addComment("):
+					mutation, input = "addComment", request.Variables.Input
+					w.Write([]byte(`{"data":{"addComment":{"commentEdge":{"node":{"url":"https://github.com/kubernetes/sig-release/issues/1#issuecomment-2"}}}}}`)) // nolint
+				case strings.Contains(request.Query, "updateIssueComment("):
+					mutation, input = "updateIssueComment", request.Variables.Input
+					w.Write([]byte(`{"data":{"updateIssueComment":{"issueComment":{"url":"https://github.com/kubernetes/sig-release/issues/1#issuecomment-1"}}}}`)) // nolint
+				default:
+					w.Write([]byte(`{"data":{"repository":{"issue":{"id":"issue-id","comments":{"nodes":[` + tt.comments + // nolint
+						`],"pageInfo":{"hasPreviousPage":false,"startCursor":"c"}}}}}}`))
+				}
+			}))
+			defer server.Close()
+
+			gh := &ProjectManager{githubClient: g4.NewEnterpriseClient(server.URL, server.Client())}
+			url, err := gh.UpsertRollupComment(TrackingIssue{Owner: "kubernetes", Repo: "sig-release", Number: 1}, "| report |")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantURL, url)
+			assert.Equal(t, tt.wantMutation, mutation)
+			assert.Equal(t, tt.wantInput, input)
+			assert.Equal(t, Stats{Queries: 1, Mutations: 1}, gh.Stats())
+		})
+	}
+}
+
+func TestUpsertRollupCommentNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"repository":{"issue":null}},"errors":[{"message":"Could not resolve to an issue or pull request with the number of 1."}]}`)) // nolint
+	}))
+	defer server.Close()
+
+	gh := &ProjectManager{githubClient: g4.NewEnterpriseClient(server.URL, server.Client())}
+	_, err := gh.UpsertRollupComment(TrackingIssue{Owner: "kubernetes", Repo: "sig-release", Number: 1}, "| report |")
+	assert.ErrorIs(t, err, ErrTrackingIssueNotFound)
+	assert.NotErrorIs(t, err, ErrProjectNotFound)
+	assert.ErrorContains(t, err, "--tracking-issue kubernetes/sig-release#1")
+}
@@ -0,0 +1,124 @@
+package output
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// junitSuites is the root element of the JUnit report
+type junitSuites struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Name     string       `xml:"name,attr"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Skipped  int          `xml:"skipped,attr"`
+	Suites   []junitSuite `xml:"testsuite"`
+}
+
+// junitSuite holds the tests of a dashboard
+type junitSuite struct {
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Skipped  int         `xml:"skipped,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+// junitCase is a failing or flaky test, the class name is its tab
+type junitCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+// junitMessage is the outcome of a test case, with the counts in its message
+// and the links in its body
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Body    string `xml:",chardata"`
+}
+
+// renderJUnit writes the findings as JUnit XML, a test suite per dashboard in
+// the order of the findings. A test with failures is a failure, a flaky test
+// without failures is skipped, so CI viewers show the flakes without failing on
+// them.
+func renderJUnit(w io.Writer, findings []Finding) error {
+	report := junitSuites{Name: "signalhound"}
+	suites := map[string]int{}
+	for _, finding := range findings {
+		i, ok := suites[finding.Dashboard]
+		if !ok {
+			i = len(report.Suites)
+			suites[finding.Dashboard] = i
+			report.Suites = append(report.Suites, junitSuite{Name: finding.Dashboard})
+		}
+		suite := &report.Suites[i]
+		testCase := junitCase{ClassName: finding.Tab, Name: finding.TestName}
+		outcome := &junitMessage{Message: junitCounts(finding), Type: finding.State, Body: junitBody(finding)}
+		if finding.FailureCount > 0 || finding.FlakeCount == 0 {
+			testCase.Failure = outcome
+			suite.Failures++
+		} else {
+			testCase.Skipped = outcome
+			suite.Skipped++
+		}
+		suite.Tests++
+		suite.Cases = append(suite.Cases, testCase)
+		report.Tests++
+	}
+	for _, suite := range report.Suites {
+		report.Failures += suite.Failures
+		report.Skipped += suite.Skipped
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// junitCounts renders the failure and flake counts of the test, e.g. 3
+// failures, 1 flake in 10 runs.
+func junitCounts(finding Finding) string {
+	counts := fmt.Sprintf("%s, %s", plural(finding.FailureCount, "failure"), plural(finding.FlakeCount, "flake"))
+	if finding.TotalRuns > 0 {
+		counts += fmt.Sprintf(" in %s", plural(finding.TotalRuns, "run"))
+	}
+	return counts
+}
+
+// junitBody lists the links and the error message of the test, one per line.
+func junitBody(finding Finding) string {
+	lines := []string{"TestGrid: " + finding.TabURL}
+	if finding.ProwJobURL != "" {
+		lines = append(lines, "Prow: "+finding.ProwJobURL)
+	}
+	if finding.TriageURL != "" {
+		lines = append(lines, "Triage: "+finding.TriageURL)
+	}
+	if len(finding.LinkedBugs) > 0 {
+		lines = append(lines, "Bugs: "+strings.Join(finding.LinkedBugs, ", "))
+	}
+	if finding.ErrorMessage != "" {
+		lines = append(lines, "", finding.ErrorMessage)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// plural renders the count with the noun, pluralized unless it is one.
+func plural(count int, noun string) string {
+	if count == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", count, noun)
+}
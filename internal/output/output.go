@@ -0,0 +1,709 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"sigs.k8s.io/signalhound/api/v1alpha1"
+	"sigs.k8s.io/signalhound/internal/testgrid"
+)
+
+const (
+	FormatTable    = "table"
+	FormatJSON     = "json"
+	FormatMarkdown = "markdown"
+	FormatJUnit    = "junit"
+	FormatTemplate = "template"
+)
+
+const (
+	GroupByDashboard = "dashboard"
+	GroupBySIG       = "sig"
+	GroupByNone      = "none"
+)
+
+const (
+	ColorAuto   = "auto"
+	ColorAlways = "always"
+	ColorNever  = "never"
+)
+
+// noSIG is the group of the tests without a SIG label
+const noSIG = "unknown"
+
+var (
+	Formats  = []string{FormatTable, FormatJSON, FormatMarkdown, FormatJUnit, FormatTemplate}
+	GroupBys = []string{GroupByDashboard, GroupBySIG, GroupByNone}
+	Colors   = []string{ColorAuto, ColorAlways, ColorNever}
+)
+
+// stateColors are the ANSI colors of the tab states in the table format, every
+// code has the same length so the tabwriter keeps the columns aligned
+var stateColors = map[string]string{
+	v1alpha1.FAILING_STATUS: "\x1b[31m",
+	v1alpha1.FLAKY_STATUS:   "\x1b[35m",
+	v1alpha1.PASSING_STATUS: "\x1b[32m",
+}
+
+const (
+	colorDefault = "\x1b[39m"
+	colorReset   = "\x1b[0m"
+)
+
+// Options holds the settings of the rendered report
+type Options struct {
+	// Format is one of Formats, the JUnit and template formats are never grouped.
+	Format string
+	// Template is the Go template of the template format, rendering each
+	// Finding on its line, with \t and \n escapes.
+	Template string
+	// GroupBy is one of GroupBys, defaults to GroupByDashboard.
+	GroupBy string
+	// Color colorizes the states in the table format.
+	Color bool
+	// Dashboards are the scraped dashboards, listed with their number of
+	// reported tests in a footer of the table and markdown formats, including
+	// the clean ones. No footer is rendered when empty.
+	Dashboards []string
+	// MinTestsAffected moves the tabs with at least this number of distinct
+	// failing tests to a widespread breakage section of the table and markdown
+	// formats, instead of listing their tests, 0 disables it.
+	MinTestsAffected int
+	// StatusMessages includes the status message TestGrid wrote for each tab.
+	StatusMessages bool
+	// TestGridURL is the base URL of the dashboard links of the footer,
+	// defaults to testgrid.URL.
+	TestGridURL string
+	// ShowHistory adds a history column to the table and markdown formats
+	// with the results of the last runs of each test, at most this number,
+	// see testgrid.History. 0 disables it.
+	ShowHistory int
+}
+
+// ResolveColor returns whether colors are enabled for the color mode, auto
+// enables them on a terminal unless the NO_COLOR environment variable is set.
+func ResolveColor(mode string, isTerminal bool) (bool, error) {
+	switch mode {
+	case ColorAlways:
+		return true, nil
+	case ColorNever:
+		return false, nil
+	case ColorAuto:
+		return isTerminal && os.Getenv("NO_COLOR") == "", nil
+	}
+	return false, fmt.Errorf("unknown color %q, valid values are: %s", mode, strings.Join(Colors, ", "))
+}
+
+// Validate returns an error on unknown format or grouping, or on an invalid
+// template of the template format.
+func (o Options) Validate() error {
+	if !contains(Formats, o.Format) {
+		return fmt.Errorf("unknown output format %q, valid values are: %s", o.Format, strings.Join(Formats, ", "))
+	}
+	if o.GroupBy != "" && !contains(GroupBys, o.GroupBy) {
+		return fmt.Errorf("unknown group-by %q, valid values are: %s", o.GroupBy, strings.Join(GroupBys, ", "))
+	}
+	if o.Format == FormatTemplate {
+		if _, err := parseTemplate(o.Template); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Finding is a single failing or flaky test of a tab, the data every format
+// renders, built from the tabs by Findings.
+type Finding struct {
+	Dashboard string `json:"dashboard"`
+	Tab       string `json:"tab"`
+	// State is the status of the tab in TestGrid, e.g. FAILING.
+	State string `json:"state"`
+	// Health is the state of the tab classified from its tests, see
+	// testgrid.TabHealth, and HealthGlyph its glyph.
+	Health          string   `json:"tab_health"`
+	HealthGlyph     string   `json:"tab_health_glyph"`
+	TestName        string   `json:"test_name"`
+	SIGs            []string `json:"sigs,omitempty"`
+	TabURL          string   `json:"tab_url"`
+	ProwJobURL      string   `json:"prow_url,omitempty"`
+	TriageURL       string   `json:"triage_url,omitempty"`
+	FirstTimestamp  int64    `json:"first_timestamp"`
+	LatestTimestamp int64    `json:"latest_timestamp"`
+	TotalRuns       int      `json:"total_runs"`
+	FailureCount    int      `json:"failures"`
+	FlakeCount      int      `json:"flakes"`
+	FailureRate     float64  `json:"failure_rate"`
+	FlakeRate       float64  `json:"flake_rate"`
+	// Severity is the filing severity of the test, see testgrid.SeverityLevel.
+	Severity   string   `json:"severity"`
+	LinkedBugs []string `json:"linked_bugs,omitempty"`
+	Alerting   bool     `json:"alerting,omitempty"`
+	// ErrorMessage is the failure message of the latest failed run, only
+	// rendered in the JUnit format.
+	ErrorMessage string `json:"-"`
+	// DurationSeconds and BaselineDurationSeconds are only set when the
+	// durations were fetched.
+	DurationSeconds         int64 `json:"duration_seconds,omitempty"`
+	BaselineDurationSeconds int64 `json:"baseline_duration_seconds,omitempty"`
+	Slow                    bool  `json:"slow,omitempty"`
+	DurationRegressed       bool  `json:"duration_regressed,omitempty"`
+	// HiddenTests is the number of tests of the tab left out by the limit per tab.
+	HiddenTests int `json:"tab_hidden_tests,omitempty"`
+	// TabStatus is the status message of the tab, only set with StatusMessages.
+	TabStatus string `json:"tab_status,omitempty"`
+	// Widespread is set on the tests of the tabs with at least MinTestsAffected
+	// failing tests.
+	Widespread bool `json:"tab_widespread,omitempty"`
+	// History is the history column, right aligned on the newest run, only
+	// set with ShowHistory.
+	History string `json:"-"`
+}
+
+// group is a named set of rows, the name is empty when grouping by none
+type group struct {
+	name string
+	rows []Finding
+}
+
+// Render writes the dashboard tabs to the writer in the format of the options.
+func Render(w io.Writer, tabs []*v1alpha1.DashboardTab, opts Options) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+	rows := Findings(tabs, opts)
+
+	switch opts.Format {
+	case FormatJSON:
+		return renderJSON(w, groupRows(rows, opts.GroupBy), opts.GroupBy)
+	case FormatJUnit:
+		return renderJUnit(w, rows)
+	case FormatTemplate:
+		return renderTemplate(w, rows, opts.Template)
+	case FormatMarkdown:
+		if err := renderMarkdownWidespread(w, widespreadTabs(tabs, opts.MinTestsAffected)); err != nil {
+			return err
+		}
+		if err := renderMarkdown(w, groupRows(regularRows(rows), opts.GroupBy), opts.ShowHistory > 0); err != nil {
+			return err
+		}
+		return renderMarkdownFooter(w, coverage(rows, opts))
+	default:
+		if err := renderTableWidespread(w, widespreadTabs(tabs, opts.MinTestsAffected)); err != nil {
+			return err
+		}
+		if err := renderTable(w, groupRows(regularRows(rows), opts.GroupBy), opts.Color, opts.ShowHistory > 0); err != nil {
+			return err
+		}
+		return renderTableFooter(w, coverage(rows, opts))
+	}
+}
+
+// widespreadTabs returns the tabs with at least min distinct failing tests.
+func widespreadTabs(tabs []*v1alpha1.DashboardTab, min int) (widespread []*v1alpha1.DashboardTab) {
+	for _, tab := range tabs {
+		if testgrid.Widespread(tab, min) {
+			widespread = append(widespread, tab)
+		}
+	}
+	return widespread
+}
+
+// regularRows returns the rows of the tabs not in the widespread section.
+func regularRows(rows []Finding) []Finding {
+	regular := make([]Finding, 0, len(rows))
+	for _, r := range rows {
+		if !r.Widespread {
+			regular = append(regular, r)
+		}
+	}
+	return regular
+}
+
+func renderTableWidespread(w io.Writer, tabs []*v1alpha1.DashboardTab) error {
+	if len(tabs) == 0 {
+		return nil
+	}
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "== Widespread breakage, probable infra or systemic issues ==")
+	fmt.Fprintln(tw, "DASHBOARD\tTAB\tFAILING TESTS\tURL")
+	for _, tab := range tabs {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\n", tab.DashboardName, tab.TabName, testgrid.FailingTests(tab), tab.TabURL)
+	}
+	fmt.Fprintln(tw)
+	return tw.Flush()
+}
+
+func renderMarkdownWidespread(w io.Writer, tabs []*v1alpha1.DashboardTab) error {
+	if len(tabs) == 0 {
+		return nil
+	}
+	fmt.Fprintln(w, "## Widespread breakage, probable infra or systemic issues")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "| Dashboard | Tab | Failing tests |")
+	fmt.Fprintln(w, "|-----------|-----|---------------|")
+	for _, tab := range tabs {
+		fmt.Fprintf(w, "| %s | [%s](%s) | %d |\n", tab.DashboardName, tab.TabName, tab.TabURL, testgrid.FailingTests(tab))
+	}
+	fmt.Fprintln(w)
+	return nil
+}
+
+// dashboardCoverage is a scraped dashboard of the footer
+type dashboardCoverage struct {
+	name  string
+	url   string
+	tests int
+}
+
+// coverage returns the scraped dashboards of the options with the number of
+// tests reported for each.
+func coverage(rows []Finding, opts Options) []dashboardCoverage {
+	base := opts.TestGridURL
+	if base == "" {
+		base = testgrid.URL
+	}
+	counts := map[string]int{}
+	for _, r := range rows {
+		counts[r.Dashboard]++
+	}
+	dashboards := make([]dashboardCoverage, len(opts.Dashboards))
+	for i, name := range opts.Dashboards {
+		dashboards[i] = dashboardCoverage{name: name, url: testgrid.DashboardURL(base, name), tests: counts[name]}
+	}
+	return dashboards
+}
+
+// testCount renders the number of tests reported for a dashboard of the footer.
+func (d dashboardCoverage) testCount() string {
+	switch d.tests {
+	case 0:
+		return "clean"
+	case 1:
+		return "1 test"
+	}
+	return fmt.Sprintf("%d tests", d.tests)
+}
+
+func renderTableFooter(w io.Writer, dashboards []dashboardCoverage) error {
+	if len(dashboards) == 0 {
+		return nil
+	}
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "\n== Scraped dashboards (%d) ==\n", len(dashboards))
+	for _, d := range dashboards {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", d.name, d.testCount(), d.url)
+	}
+	return tw.Flush()
+}
+
+func renderMarkdownFooter(w io.Writer, dashboards []dashboardCoverage) error {
+	if len(dashboards) == 0 {
+		return nil
+	}
+	fmt.Fprintf(w, "\n## Scraped dashboards (%d)\n\n", len(dashboards))
+	fmt.Fprintln(w, "| Dashboard | Tests |")
+	fmt.Fprintln(w, "|-----------|-------|")
+	for _, d := range dashboards {
+		fmt.Fprintf(w, "| [%s](%s) | %s |\n", d.name, d.url, d.testCount())
+	}
+	return nil
+}
+
+// WriteFile renders the dashboard tabs to the file, atomically: the report is
+// written to a temporary file of the same directory then renamed, so readers
+// never see a partial report.
+func WriteFile(path string, tabs []*v1alpha1.DashboardTab, opts Options) error {
+	return writeAtomic(path, func(w io.Writer) error {
+		return Render(w, tabs, opts)
+	})
+}
+
+// writeAtomic writes the file with a temporary file of the same directory
+// renamed once the render succeeded.
+func writeAtomic(path string, render func(w io.Writer) error) error {
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	// the temporary file is left behind only when the rename fails
+	defer os.Remove(file.Name()) // nolint:errcheck
+	if err = render(file); err != nil {
+		file.Close() // nolint:errcheck
+		return err
+	}
+	if err = file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
+
+// IndexName is the base name of the index written by WriteDir, with the
+// extension of the format.
+const IndexName = "index"
+
+// formatExtensions are the file extensions of the reports written by WriteDir.
+var formatExtensions = map[string]string{
+	FormatTable:    ".txt",
+	FormatJSON:     ".json",
+	FormatMarkdown: ".md",
+	FormatJUnit:    ".xml",
+	FormatTemplate: ".txt",
+}
+
+// indexEntry is a report listed in the index of WriteDir
+type indexEntry struct {
+	Dashboard string `json:"dashboard"`
+	File      string `json:"file"`
+	Tests     int    `json:"tests"`
+}
+
+// WriteDir renders one report per dashboard in the directory, created when
+// missing, each named after its dashboard with the extension of the format,
+// and an index listing them, a table for the JUnit and template formats.
+// The dashboards are the ones of the options, so the clean dashboards get a
+// report too, else the ones of the tabs. Every file is written atomically,
+// the index last, and the paths of the written reports are returned.
+func WriteDir(dir string, tabs []*v1alpha1.DashboardTab, opts Options) ([]string, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	dashboards := opts.Dashboards
+	byDashboard := map[string][]*v1alpha1.DashboardTab{}
+	for _, tab := range tabs {
+		if _, ok := byDashboard[tab.DashboardName]; !ok && len(opts.Dashboards) == 0 {
+			dashboards = append(dashboards, tab.DashboardName)
+		}
+		byDashboard[tab.DashboardName] = append(byDashboard[tab.DashboardName], tab)
+	}
+
+	ext := formatExtensions[opts.Format]
+	counts := map[string]int{}
+	for _, r := range Findings(tabs, opts) {
+		counts[r.Dashboard]++
+	}
+	paths := make([]string, 0, len(dashboards))
+	index := make([]indexEntry, 0, len(dashboards))
+	for _, dashboard := range dashboards {
+		name := reportName(dashboard) + ext
+		dashboardOpts := opts
+		dashboardOpts.Dashboards = []string{dashboard}
+		path := filepath.Join(dir, name)
+		if err := WriteFile(path, byDashboard[dashboard], dashboardOpts); err != nil {
+			return paths, err
+		}
+		paths = append(paths, path)
+		index = append(index, indexEntry{Dashboard: dashboard, File: name, Tests: counts[dashboard]})
+	}
+	indexFormat := opts.Format
+	if indexFormat == FormatJUnit || indexFormat == FormatTemplate {
+		indexFormat = FormatTable
+	}
+	return paths, writeAtomic(filepath.Join(dir, IndexName+formatExtensions[indexFormat]), func(w io.Writer) error {
+		return renderIndex(w, index, indexFormat)
+	})
+}
+
+// reportName returns the file name of the dashboard report, without the path
+// separators and spaces a dashboard name may contain.
+func reportName(dashboard string) string {
+	return strings.NewReplacer("/", "-", `\`, "-", " ", "-").Replace(dashboard)
+}
+
+func renderIndex(w io.Writer, index []indexEntry, format string) error {
+	switch format {
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(index)
+	case FormatMarkdown:
+		fmt.Fprintf(w, "# Reports (%d)\n\n", len(index))
+		fmt.Fprintln(w, "| Dashboard | Tests |")
+		fmt.Fprintln(w, "|-----------|-------|")
+		for _, entry := range index {
+			fmt.Fprintf(w, "| [%s](%s) | %d |\n", entry.Dashboard, entry.File, entry.Tests)
+		}
+		return nil
+	default:
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "DASHBOARD\tTESTS\tFILE")
+		for _, entry := range index {
+			fmt.Fprintf(tw, "%s\t%d\t%s\n", entry.Dashboard, entry.Tests, entry.File)
+		}
+		return tw.Flush()
+	}
+}
+
+// Findings returns a finding per test of the tabs, in the order of the tabs.
+func Findings(tabs []*v1alpha1.DashboardTab, opts Options) (rows []Finding) {
+	for _, tab := range tabs {
+		var tabStatus string
+		if opts.StatusMessages {
+			tabStatus = tab.StatusMessage
+		}
+		widespread := testgrid.Widespread(tab, opts.MinTestsAffected)
+		health, glyph := testgrid.TabHealth(tab), testgrid.HealthGlyph(tab)
+		for _, test := range tab.TestRuns {
+			rows = append(rows, Finding{
+				Dashboard:               tab.DashboardName,
+				Tab:                     tab.TabName,
+				State:                   tab.TabState,
+				Health:                  health,
+				HealthGlyph:             glyph,
+				TestName:                test.TestName,
+				SIGs:                    testgrid.ExtractSIGs(test.TestName),
+				TabURL:                  tab.TabURL,
+				ProwJobURL:              test.ProwJobURL,
+				TriageURL:               test.TriageURL,
+				FirstTimestamp:          test.FirstTimestamp,
+				LatestTimestamp:         test.LatestTimestamp,
+				TotalRuns:               test.TotalRuns,
+				FailureCount:            test.FailureCount,
+				FlakeCount:              test.FlakeCount,
+				FailureRate:             test.FailureRate(),
+				FlakeRate:               test.FlakeRate(),
+				Severity:                testgrid.SeverityLevel(tab.TabState, &test),
+				LinkedBugs:              test.LinkedBugs,
+				Alerting:                test.Alerting,
+				ErrorMessage:            test.ErrorMessage,
+				DurationSeconds:         test.DurationSeconds,
+				BaselineDurationSeconds: test.BaselineDurationSeconds,
+				Slow:                    test.Slow,
+				DurationRegressed:       test.DurationRegressed(),
+				HiddenTests:             tab.HiddenTests,
+				TabStatus:               tabStatus,
+				Widespread:              widespread,
+				History:                 historyCell(test.RunResults, opts.ShowHistory),
+			})
+		}
+	}
+	return rows
+}
+
+// groupRows splits the rows by the group key, sorted by name. A test labeled
+// with multiple SIGs is listed under each of them.
+func groupRows(rows []Finding, groupBy string) []group {
+	if groupBy == GroupByNone {
+		return []group{{rows: rows}}
+	}
+
+	byName := map[string][]Finding{}
+	for _, r := range rows {
+		names := []string{r.Dashboard}
+		if groupBy == GroupBySIG {
+			names = r.SIGs
+			if len(names) == 0 {
+				names = []string{noSIG}
+			}
+		}
+		for _, name := range names {
+			byName[name] = append(byName[name], r)
+		}
+	}
+
+	groups := make([]group, 0, len(byName))
+	for name, rows := range byName {
+		groups = append(groups, group{name: name, rows: rows})
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].name < groups[j].name })
+	return groups
+}
+
+func renderJSON(w io.Writer, groups []group, groupBy string) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if groupBy == GroupByNone {
+		rows := groups[0].rows
+		if rows == nil {
+			rows = []Finding{}
+		}
+		return encoder.Encode(rows)
+	}
+	nested := make(map[string][]Finding, len(groups))
+	for _, g := range groups {
+		nested[g.name] = g.rows
+	}
+	return encoder.Encode(nested)
+}
+
+func renderTable(w io.Writer, groups []group, color, history bool) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for i, g := range groups {
+		if g.name != "" {
+			if i > 0 {
+				fmt.Fprintln(tw)
+			}
+			fmt.Fprintf(tw, "== %s ==\n", g.name)
+		}
+		fmt.Fprintf(tw, "%s\tDASHBOARD\tTAB\tFAIL%%\tFLAKE%%\t%sTEST\n", colorState("STATE", color), column("HISTORY", history, "\t"))
+		for j, r := range g.rows {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s%s\n", colorState(r.State, color), r.Dashboard, tabCell(r),
+				percent(r.FailureRate, r.TotalRuns), percent(r.FlakeRate, r.TotalRuns), column(r.History, history, "\t"), r.TestName+annotations(r))
+			if hidden := hiddenAfter(g.rows, j); hidden > 0 {
+				fmt.Fprintf(tw, "%s\t%s\t%s\t\t\t%s%s\n", colorState(r.State, color), r.Dashboard, tabCell(r), column("", history, "\t"), moreTests(hidden))
+			}
+			if status := statusAfter(g.rows, j); status != "" {
+				fmt.Fprintf(tw, "%s\t%s\t%s\t\t\t%s%s\n", colorState(r.State, color), r.Dashboard, tabCell(r), column("", history, "\t"), status)
+			}
+		}
+	}
+	return tw.Flush()
+}
+
+func renderMarkdown(w io.Writer, groups []group, history bool) error {
+	for i, g := range groups {
+		if g.name != "" {
+			if i > 0 {
+				fmt.Fprintln(w)
+			}
+			fmt.Fprintf(w, "## %s\n\n", g.name)
+		}
+		fmt.Fprintf(w, "| State | Dashboard | Tab | Fail %% | Flake %% | %sTest |\n", column("History", history, " | "))
+		fmt.Fprintf(w, "|-------|-----------|-----|--------|---------|%s------|\n", column("---------", history, "|"))
+		for j, r := range g.rows {
+			fmt.Fprintf(w, "| %s | %s | %s [%s](%s) | %s | %s | %s%s |\n", r.State, r.Dashboard, r.HealthGlyph, r.Tab, r.TabURL,
+				percent(r.FailureRate, r.TotalRuns), percent(r.FlakeRate, r.TotalRuns), column(markdownHistory(r.History), history, " | "), markdownTest(r))
+			if hidden := hiddenAfter(g.rows, j); hidden > 0 {
+				fmt.Fprintf(w, "| %s | %s | %s [%s](%s) | | | %s%s |\n", r.State, r.Dashboard, r.HealthGlyph, r.Tab, r.TabURL, column("", history, " | "), moreTests(hidden))
+			}
+			if status := statusAfter(g.rows, j); status != "" {
+				fmt.Fprintf(w, "| %s | %s | %s [%s](%s) | | | %s%s |\n", r.State, r.Dashboard, r.HealthGlyph, r.Tab, r.TabURL, column("", history, " | "), strings.ReplaceAll(status, "|", "\\|"))
+			}
+		}
+	}
+	return nil
+}
+
+// column renders an optional cell followed by its separator, empty when the
+// column is disabled.
+func column(cell string, enabled bool, separator string) string {
+	if !enabled {
+		return ""
+	}
+	return cell + separator
+}
+
+// historyCell renders the last n results of a test, padded on the left so the
+// newest runs of the tests line up, empty when n is 0.
+func historyCell(results []v1alpha1.RunResult, n int) string {
+	if n <= 0 {
+		return ""
+	}
+	history := testgrid.History(results, n)
+	return strings.Repeat(" ", n-len(history)) + history
+}
+
+// markdownHistory renders the history in a code span, keeping its padding.
+func markdownHistory(history string) string {
+	if strings.TrimSpace(history) == "" {
+		return ""
+	}
+	return "`" + history + "`"
+}
+
+// tabCell renders the tab of the row with the glyph of its health.
+func tabCell(r Finding) string {
+	return r.HealthGlyph + " " + r.Tab
+}
+
+// markdownTest links the test to its prow job when available.
+func markdownTest(r Finding) string {
+	name := strings.ReplaceAll(r.TestName, "|", "\\|")
+	if r.ProwJobURL != "" {
+		name = fmt.Sprintf("[%s](%s)", name, r.ProwJobURL)
+	}
+	return name + strings.ReplaceAll(annotations(r), "|", "\\|")
+}
+
+// annotations renders the linked bugs and the duration notes after the test name.
+func annotations(r Finding) string {
+	return linkedBugs(r.LinkedBugs) + durationNote(r)
+}
+
+// durationNote flags a slow test or a test whose duration regressed.
+func durationNote(r Finding) string {
+	duration := time.Duration(r.DurationSeconds) * time.Second
+	switch {
+	case r.DurationRegressed:
+		return fmt.Sprintf(" (duration regressed: %s → %s)", time.Duration(r.BaselineDurationSeconds)*time.Second, duration)
+	case r.Slow:
+		return fmt.Sprintf(" (slow: %s)", duration)
+	}
+	return ""
+}
+
+// linkedBugs renders the bugs linked to a test in TestGrid after its name.
+func linkedBugs(bugs []string) string {
+	if len(bugs) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (bug: %s)", strings.Join(bugs, ", "))
+}
+
+// colorState wraps the state with its ANSI color when colors are enabled, the
+// default color is used for unknown states and the header.
+func colorState(state string, color bool) string {
+	if !color {
+		return state
+	}
+	code, ok := stateColors[state]
+	if !ok {
+		code = colorDefault
+	}
+	return code + state + colorReset
+}
+
+// hiddenAfter returns the number of hidden tests of the tab when the row is
+// the last one of its tab, 0 otherwise.
+func hiddenAfter(rows []Finding, i int) int {
+	if followedInTab(rows, i) {
+		return 0
+	}
+	return rows[i].HiddenTests
+}
+
+// followedInTab reports whether the row is followed by another row of its tab.
+func followedInTab(rows []Finding, i int) bool {
+	return i+1 < len(rows) && rows[i+1].Dashboard == rows[i].Dashboard && rows[i+1].Tab == rows[i].Tab
+}
+
+// statusAfter renders the TestGrid status message of the tab when the row is
+// the last one of its tab, empty otherwise or when the tab has none.
+func statusAfter(rows []Finding, i int) string {
+	if rows[i].TabStatus == "" || followedInTab(rows, i) {
+		return ""
+	}
+	return "TestGrid: " + rows[i].TabStatus
+}
+
+// moreTests renders the indicator of the tests hidden by the limit per tab.
+func moreTests(hidden int) string {
+	return fmt.Sprintf("…and %d more", hidden)
+}
+
+// percent renders a rate as a percentage, or "-" when the test has no runs.
+func percent(rate float64, runs int) string {
+	if runs == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f%%", rate*100)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,556 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/signalhound/api/v1alpha1"
+)
+
+func sampleTabs() []*v1alpha1.DashboardTab {
+	return []*v1alpha1.DashboardTab{
+		{
+			DashboardName: "sig-release-master-informing",
+			TabName:       "gce-cos-master-serial",
+			TabState:      v1alpha1.FLAKY_STATUS,
+			TestRuns: []v1alpha1.TestResult{
+				{TestName: "Kubernetes e2e suite.[It] [sig-node] Pods should restart", FlakeCount: 1, TotalRuns: 4},
+			},
+		},
+		{
+			DashboardName: "sig-release-master-blocking",
+			TabName:       "build-master",
+			TabState:      v1alpha1.FAILING_STATUS,
+			TestRuns: []v1alpha1.TestResult{
+				{TestName: "ci-kubernetes-build.Overall", ProwJobURL: "https://prow.k8s.io/view/gs/build/1", FailureCount: 3, TotalRuns: 4},
+				{TestName: "Kubernetes e2e suite.[It] [sig-node] [sig-apps] Deployment should roll"},
+			},
+		},
+	}
+}
+
+func TestOptionsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    Options
+		wantErr bool
+	}{
+		{name: "table grouped by dashboard", opts: Options{Format: FormatTable, GroupBy: GroupByDashboard}},
+		{name: "default grouping", opts: Options{Format: FormatJSON}},
+		{name: "unknown format", opts: Options{Format: "yaml"}, wantErr: true},
+		{name: "unknown grouping", opts: Options{Format: FormatTable, GroupBy: "tab"}, wantErr: true},
+		{name: "template", opts: Options{Format: FormatTemplate, Template: "{{.Dashboard}}"}},
+		{name: "template missing", opts: Options{Format: FormatTemplate}, wantErr: true},
+		{name: "template syntax", opts: Options{Format: FormatTemplate, Template: "{{.Dashboard"}, wantErr: true},
+		{name: "template unknown field", opts: Options{Format: FormatTemplate, Template: "{{.Test}}"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestRenderJSONGroups(t *testing.T) {
+	tests := []struct {
+		name     string
+		groupBy  string
+		expected map[string]int
+	}{
+		{
+			name:     "by dashboard",
+			groupBy:  GroupByDashboard,
+			expected: map[string]int{"sig-release-master-blocking": 2, "sig-release-master-informing": 1},
+		},
+		{
+			name:     "by sig",
+			groupBy:  GroupBySIG,
+			expected: map[string]int{"node": 2, "apps": 1, noSIG: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			assert.NoError(t, Render(&buf, sampleTabs(), Options{Format: FormatJSON, GroupBy: tt.groupBy}))
+
+			var nested map[string][]Finding
+			assert.NoError(t, json.Unmarshal(buf.Bytes(), &nested))
+			counts := map[string]int{}
+			for name, rows := range nested {
+				counts[name] = len(rows)
+			}
+			assert.Equal(t, tt.expected, counts)
+		})
+	}
+}
+
+func TestRenderJSONWithoutGroups(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, Render(&buf, sampleTabs(), Options{Format: FormatJSON, GroupBy: GroupByNone}))
+
+	var rows []Finding
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &rows))
+	assert.Len(t, rows, 3)
+
+	buf.Reset()
+	assert.NoError(t, Render(&buf, nil, Options{Format: FormatJSON, GroupBy: GroupByNone}))
+	assert.Equal(t, "[]\n", buf.String())
+}
+
+func TestRenderTable(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, Render(&buf, sampleTabs(), Options{Format: FormatTable, GroupBy: GroupByDashboard}))
+	output := buf.String()
+	assert.Contains(t, output, "== sig-release-master-blocking ==")
+	assert.Contains(t, output, "== sig-release-master-informing ==")
+	assert.Less(t, bytes.Index(buf.Bytes(), []byte("blocking ==")), bytes.Index(buf.Bytes(), []byte("informing ==")))
+
+	buf.Reset()
+	assert.NoError(t, Render(&buf, sampleTabs(), Options{Format: FormatTable, GroupBy: GroupByNone}))
+	assert.NotContains(t, buf.String(), "==")
+	assert.Contains(t, buf.String(), "25%")
+	assert.Contains(t, buf.String(), "75%")
+}
+
+func TestResolveColor(t *testing.T) {
+	tests := []struct {
+		name       string
+		mode       string
+		isTerminal bool
+		noColor    string
+		expected   bool
+		wantErr    bool
+	}{
+		{name: "auto on a terminal", mode: ColorAuto, isTerminal: true, expected: true},
+		{name: "auto piped", mode: ColorAuto},
+		{name: "auto with NO_COLOR", mode: ColorAuto, isTerminal: true, noColor: "1"},
+		{name: "always piped", mode: ColorAlways, expected: true},
+		{name: "always overrides NO_COLOR", mode: ColorAlways, noColor: "1", expected: true},
+		{name: "never on a terminal", mode: ColorNever, isTerminal: true},
+		{name: "unknown mode", mode: "sometimes", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NO_COLOR", tt.noColor)
+			color, err := ResolveColor(tt.mode, tt.isTerminal)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, color)
+		})
+	}
+}
+
+func TestRenderTableColor(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, Render(&buf, sampleTabs(), Options{Format: FormatTable, Color: true}))
+	assert.Contains(t, buf.String(), stateColors[v1alpha1.FAILING_STATUS]+v1alpha1.FAILING_STATUS+colorReset)
+
+	buf.Reset()
+	assert.NoError(t, Render(&buf, sampleTabs(), Options{Format: FormatTable}))
+	assert.NotContains(t, buf.String(), colorReset)
+}
+
+func TestRenderHiddenTests(t *testing.T) {
+	tabs := sampleTabs()
+	tabs[1].HiddenTests = 4
+
+	var buf bytes.Buffer
+	assert.NoError(t, Render(&buf, tabs, Options{Format: FormatMarkdown, GroupBy: GroupByDashboard}))
+	assert.Equal(t, 1, bytes.Count(buf.Bytes(), []byte("…and 4 more")))
+
+	buf.Reset()
+	assert.NoError(t, Render(&buf, tabs, Options{Format: FormatTable, GroupBy: GroupByNone}))
+	assert.Equal(t, 1, bytes.Count(buf.Bytes(), []byte("…and 4 more")))
+
+	buf.Reset()
+	assert.NoError(t, Render(&buf, tabs, Options{Format: FormatJSON, GroupBy: GroupByNone}))
+	var rows []Finding
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &rows))
+	assert.Equal(t, 4, rows[1].HiddenTests)
+}
+
+func TestRenderStatusMessages(t *testing.T) {
+	tabs := sampleTabs()
+	tabs[0].StatusMessage = "3 of 4 (75.0%) recent columns passed"
+	tabs[1].StatusMessage = "1 of 3 | 2 failing"
+
+	var buf bytes.Buffer
+	assert.NoError(t, Render(&buf, tabs, Options{Format: FormatMarkdown, GroupBy: GroupByDashboard, StatusMessages: true}))
+	assert.Equal(t, 1, bytes.Count(buf.Bytes(), []byte("TestGrid: 1 of 3 \\| 2 failing")))
+	assert.Contains(t, buf.String(), "TestGrid: 3 of 4 (75.0%) recent columns passed")
+
+	buf.Reset()
+	assert.NoError(t, Render(&buf, tabs, Options{Format: FormatJSON, GroupBy: GroupByNone, StatusMessages: true}))
+	var rows []Finding
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &rows))
+	assert.Equal(t, "3 of 4 (75.0%) recent columns passed", rows[0].TabStatus)
+
+	// tabs without a message and the option disabled add no line
+	tabs[0].StatusMessage = ""
+	buf.Reset()
+	assert.NoError(t, Render(&buf, tabs, Options{Format: FormatTable, GroupBy: GroupByNone, StatusMessages: true}))
+	assert.Equal(t, 1, bytes.Count(buf.Bytes(), []byte("TestGrid:")))
+	buf.Reset()
+	assert.NoError(t, Render(&buf, tabs, Options{Format: FormatTable, GroupBy: GroupByNone}))
+	assert.NotContains(t, buf.String(), "TestGrid:")
+}
+
+func TestRenderWidespread(t *testing.T) {
+	tabs := sampleTabs()
+	tabs[1].TestRuns[1].FailureCount = 1
+	tabs[1].TabURL = "https://testgrid.k8s.io/sig-release-master-blocking#build-master"
+
+	var buf bytes.Buffer
+	assert.NoError(t, Render(&buf, tabs, Options{Format: FormatTable, GroupBy: GroupByNone, MinTestsAffected: 2}))
+	output := buf.String()
+	assert.Contains(t, output, "== Widespread breakage, probable infra or systemic issues ==\n")
+	assert.Contains(t, output, "sig-release-master-blocking  build-master  2              https://testgrid.k8s.io/sig-release-master-blocking#build-master\n")
+	assert.NotContains(t, output, "ci-kubernetes-build.Overall")
+	assert.Contains(t, output, "Pods should restart")
+
+	buf.Reset()
+	assert.NoError(t, Render(&buf, tabs, Options{Format: FormatMarkdown, GroupBy: GroupByDashboard, MinTestsAffected: 2}))
+	assert.Contains(t, buf.String(), "| sig-release-master-blocking | [build-master](https://testgrid.k8s.io/sig-release-master-blocking#build-master) | 2 |\n")
+
+	buf.Reset()
+	assert.NoError(t, Render(&buf, tabs, Options{Format: FormatJSON, GroupBy: GroupByNone, MinTestsAffected: 2}))
+	var rows []Finding
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &rows))
+	assert.Len(t, rows, 3)
+	assert.False(t, rows[0].Widespread)
+	assert.True(t, rows[1].Widespread)
+
+	buf.Reset()
+	assert.NoError(t, Render(&buf, tabs, Options{Format: FormatTable, GroupBy: GroupByNone}))
+	assert.NotContains(t, buf.String(), "Widespread")
+}
+
+func TestRenderHealthGlyphs(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, Render(&buf, sampleTabs(), Options{Format: FormatTable, GroupBy: GroupByNone}))
+	assert.Contains(t, buf.String(), "sig-release-master-informing  ⚠ gce-cos-master-serial")
+	assert.Contains(t, buf.String(), "sig-release-master-blocking   ✗ build-master")
+
+	buf.Reset()
+	assert.NoError(t, Render(&buf, sampleTabs(), Options{Format: FormatMarkdown, GroupBy: GroupByNone}))
+	assert.Contains(t, buf.String(), "| ✗ [build-master]()")
+
+	buf.Reset()
+	assert.NoError(t, Render(&buf, sampleTabs(), Options{Format: FormatJSON, GroupBy: GroupByNone}))
+	var rows []Finding
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &rows))
+	assert.Equal(t, v1alpha1.FLAKY_STATUS, rows[0].Health)
+	assert.Equal(t, "⚠", rows[0].HealthGlyph)
+	assert.Equal(t, v1alpha1.FAILING_STATUS, rows[1].Health)
+}
+
+func TestRenderTemplate(t *testing.T) {
+	var buf bytes.Buffer
+	opts := Options{Format: FormatTemplate, Template: `{{.Dashboard}}\t{{.TestName}}\t{{.FailureCount}}`}
+	assert.NoError(t, Render(&buf, sampleTabs(), opts))
+	assert.Equal(t, "sig-release-master-informing\tKubernetes e2e suite.[It] [sig-node] Pods should restart\t0\n"+
+		"sig-release-master-blocking\tci-kubernetes-build.Overall\t3\n"+
+		"sig-release-master-blocking\tKubernetes e2e suite.[It] [sig-node] [sig-apps] Deployment should roll\t0\n", buf.String())
+
+	// a template ending with a newline is not given a second one
+	buf.Reset()
+	opts.Template = "{{.Tab}} {{join .SIGs \",\"}}\n"
+	assert.NoError(t, Render(&buf, sampleTabs()[:1], opts))
+	assert.Equal(t, "gce-cos-master-serial node\n", buf.String())
+}
+
+func TestRenderJUnit(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, Render(&buf, sampleTabs(), Options{Format: FormatJUnit}))
+	assert.True(t, strings.HasPrefix(buf.String(), xml.Header))
+
+	var report junitSuites
+	assert.NoError(t, xml.Unmarshal(buf.Bytes(), &report))
+	assert.Equal(t, 3, report.Tests)
+	assert.Equal(t, 2, report.Failures)
+	assert.Equal(t, 1, report.Skipped)
+	assert.Len(t, report.Suites, 2)
+
+	informing, blocking := report.Suites[0], report.Suites[1]
+	assert.Equal(t, "sig-release-master-informing", informing.Name)
+	assert.Equal(t, "gce-cos-master-serial", informing.Cases[0].ClassName)
+	assert.Nil(t, informing.Cases[0].Failure)
+	assert.Equal(t, "0 failures, 1 flake in 4 runs", informing.Cases[0].Skipped.Message)
+
+	assert.Equal(t, "sig-release-master-blocking", blocking.Name)
+	assert.Equal(t, 2, blocking.Failures)
+	failure := blocking.Cases[0].Failure
+	assert.Equal(t, "3 failures, 0 flakes in 4 runs", failure.Message)
+	assert.Equal(t, v1alpha1.FAILING_STATUS, failure.Type)
+	assert.Contains(t, failure.Body, "Prow: https://prow.k8s.io/view/gs/build/1")
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, Render(&buf, sampleTabs(), Options{Format: FormatMarkdown, GroupBy: GroupBySIG}))
+	output := buf.String()
+	assert.Contains(t, output, "## node\n")
+	assert.Contains(t, output, "[ci-kubernetes-build.Overall](https://prow.k8s.io/view/gs/build/1)")
+}
+
+func TestRenderHistory(t *testing.T) {
+	tabs := sampleTabs()
+	tabs[1].TestRuns[0].RunResults = []v1alpha1.RunResult{
+		{Result: v1alpha1.RUN_FAILED}, {Result: v1alpha1.RUN_FAILED}, {Result: v1alpha1.RUN_PASSED}, {Result: v1alpha1.RUN_FAILED},
+	}
+	tabs[0].TestRuns[0].RunResults = []v1alpha1.RunResult{{Result: v1alpha1.RUN_FLAKY}, {Result: v1alpha1.RUN_PASSED}}
+
+	var buf bytes.Buffer
+	assert.NoError(t, Render(&buf, tabs, Options{Format: FormatTable, GroupBy: GroupByNone}))
+	assert.NotContains(t, buf.String(), "HISTORY")
+
+	buf.Reset()
+	assert.NoError(t, Render(&buf, tabs, Options{Format: FormatTable, GroupBy: GroupByNone, ShowHistory: 3}))
+	lines := strings.Split(buf.String(), "\n")
+	assert.Contains(t, lines[0], "HISTORY  TEST")
+	// the newest runs line up, the glyphs are capped to the history length
+	assert.Contains(t, lines[1], "   .f      Kubernetes e2e suite")
+	assert.Contains(t, lines[2], "  .xx      ci-kubernetes-build.Overall")
+	assert.Equal(t, strings.Index(lines[1], ".f"), strings.Index(lines[2], ".xx")+1)
+
+	buf.Reset()
+	assert.NoError(t, Render(&buf, tabs, Options{Format: FormatMarkdown, GroupBy: GroupByNone, ShowHistory: 3}))
+	assert.Contains(t, buf.String(), "| Flake % | History | Test |")
+	assert.Contains(t, buf.String(), "| `.xx` | [ci-kubernetes-build.Overall]")
+	assert.Contains(t, buf.String(), "|  | Kubernetes e2e suite.[It] [sig-node] [sig-apps] Deployment should roll |")
+}
+
+func TestRenderDashboardsFooter(t *testing.T) {
+	dashboards := []string{"sig-release-master-blocking", "sig-release-master-informing", "sig-node release"}
+	tests := []struct {
+		name   string
+		format string
+		want   []string
+	}{
+		{
+			name:   "table",
+			format: FormatTable,
+			want: []string{
+				"== Scraped dashboards (3) ==\n",
+				"sig-release-master-blocking   2 tests  https://testgrid.example/sig-release-master-blocking\n",
+				"sig-release-master-informing  1 test   https://testgrid.example/sig-release-master-informing\n",
+				"sig-node release              clean    https://testgrid.example/sig-node%20release\n",
+			},
+		},
+		{
+			name:   "markdown",
+			format: FormatMarkdown,
+			want: []string{
+				"## Scraped dashboards (3)\n",
+				"| [sig-release-master-blocking](https://testgrid.example/sig-release-master-blocking) | 2 tests |\n",
+				"| [sig-node release](https://testgrid.example/sig-node%20release) | clean |\n",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			assert.NoError(t, Render(&buf, sampleTabs(), Options{
+				Format: tt.format, GroupBy: GroupBySIG, Dashboards: dashboards, TestGridURL: "https://testgrid.example",
+			}))
+			for _, want := range tt.want {
+				assert.Contains(t, buf.String(), want)
+			}
+		})
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, Render(&buf, sampleTabs(), Options{Format: FormatTable}))
+	assert.NotContains(t, buf.String(), "Scraped dashboards")
+}
+
+func TestRenderLinkedBugs(t *testing.T) {
+	tabs := []*v1alpha1.DashboardTab{{
+		DashboardName: "sig-release-master-blocking",
+		TabName:       "build-master",
+		TabState:      v1alpha1.FAILING_STATUS,
+		TestRuns: []v1alpha1.TestResult{
+			{TestName: "Overall", LinkedBugs: []string{"https://github.com/kubernetes/kubernetes/issues/1"}, Alerting: true},
+		},
+	}}
+
+	var buf bytes.Buffer
+	assert.NoError(t, Render(&buf, tabs, Options{Format: FormatTable}))
+	assert.Contains(t, buf.String(), "Overall (bug: https://github.com/kubernetes/kubernetes/issues/1)")
+
+	buf.Reset()
+	assert.NoError(t, Render(&buf, tabs, Options{Format: FormatMarkdown}))
+	assert.Contains(t, buf.String(), "| Overall (bug: https://github.com/kubernetes/kubernetes/issues/1) |")
+
+	buf.Reset()
+	assert.NoError(t, Render(&buf, tabs, Options{Format: FormatJSON, GroupBy: GroupByNone}))
+	var rows []Finding
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &rows))
+	assert.Equal(t, []string{"https://github.com/kubernetes/kubernetes/issues/1"}, rows[0].LinkedBugs)
+	assert.True(t, rows[0].Alerting)
+}
+
+func TestWriteFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "report.json")
+	assert.NoError(t, os.WriteFile(path, []byte("previous report"), 0o644))
+
+	assert.NoError(t, WriteFile(path, sampleTabs(), Options{Format: FormatJSON}))
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	var groups map[string][]Finding
+	assert.NoError(t, json.Unmarshal(data, &groups))
+	assert.Contains(t, groups, "sig-release-master-informing")
+
+	// a failed render keeps the previous report and no temporary file
+	assert.Error(t, WriteFile(path, sampleTabs(), Options{Format: "yaml"}))
+	after, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, data, after)
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestWriteDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "reports")
+	paths, err := WriteDir(dir, sampleTabs(), Options{Format: FormatMarkdown})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, paths)
+
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, len(paths)+1)
+	index, err := os.ReadFile(filepath.Join(dir, "index.md"))
+	assert.NoError(t, err)
+	for _, path := range paths {
+		assert.Contains(t, string(index), "]("+filepath.Base(path)+")")
+	}
+
+	// the dashboards of the options get a report even when clean
+	dir = t.TempDir()
+	paths, err = WriteDir(dir, nil, Options{Format: FormatJSON, Dashboards: []string{"sig-node-release-blocking"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "sig-node-release-blocking.json")}, paths)
+	data, err := os.ReadFile(filepath.Join(dir, "index.json"))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[{"dashboard":"sig-node-release-blocking","file":"sig-node-release-blocking.json","tests":0}]`, string(data))
+
+	_, err = WriteDir(dir, nil, Options{Format: "yaml"})
+	assert.Error(t, err)
+}
+
+func TestReportName(t *testing.T) {
+	assert.Equal(t, "sig-release-master-blocking", reportName("sig-release-master-blocking"))
+	assert.Equal(t, "google-gce-node-e2e", reportName("google/gce node-e2e"))
+}
+
+func TestRenderDurations(t *testing.T) {
+	tabs := []*v1alpha1.DashboardTab{{
+		DashboardName: "sig-release-master-blocking",
+		TabName:       "gce-cos-master-default",
+		TabState:      v1alpha1.FAILING_STATUS,
+		TestRuns: []v1alpha1.TestResult{
+			{TestName: "regressed", DurationSeconds: 1200, BaselineDurationSeconds: 300},
+			{TestName: "slow", DurationSeconds: 5400, BaselineDurationSeconds: 5000, Slow: true},
+		},
+	}}
+
+	var buf bytes.Buffer
+	assert.NoError(t, Render(&buf, tabs, Options{Format: FormatTable}))
+	assert.Contains(t, buf.String(), "regressed (duration regressed: 5m0s → 20m0s)")
+	assert.Contains(t, buf.String(), "slow (slow: 1h30m0s)")
+
+	buf.Reset()
+	assert.NoError(t, Render(&buf, tabs, Options{Format: FormatJSON, GroupBy: GroupByNone}))
+	var rows []Finding
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &rows))
+	assert.True(t, rows[0].DurationRegressed)
+	assert.Equal(t, int64(300), rows[0].BaselineDurationSeconds)
+	assert.True(t, rows[1].Slow)
+}
+
+func TestRenderSummary(t *testing.T) {
+	tabs := []*v1alpha1.DashboardTab{
+		{DashboardName: "sig-release-master-blocking", TabName: "build-master", TabState: v1alpha1.FAILING_STATUS, TabURL: "https://testgrid.k8s.io/sig-release-master-blocking#build-master"},
+		{DashboardName: "sig-release-master-blocking", TabName: "kind-master", TabState: v1alpha1.FLAKY_STATUS, StatusMessage: "8 of 10 (80.0%) recent columns passed"},
+	}
+
+	var table bytes.Buffer
+	assert.NoError(t, RenderSummary(&table, tabs, Options{Format: FormatTable}))
+	assert.True(t, strings.HasPrefix(table.String(), "== SUMMARY ONLY"))
+	assert.Contains(t, table.String(), "FAILING  sig-release-master-blocking  build-master")
+	assert.Contains(t, table.String(), "8 of 10 (80.0%) recent columns passed")
+
+	var markdown bytes.Buffer
+	assert.NoError(t, RenderSummary(&markdown, tabs, Options{Format: FormatMarkdown}))
+	assert.Contains(t, markdown.String(), "_SUMMARY ONLY")
+	assert.Contains(t, markdown.String(), "| FAILING | sig-release-master-blocking | [build-master](https://testgrid.k8s.io/sig-release-master-blocking#build-master) |  |")
+
+	var out bytes.Buffer
+	assert.NoError(t, RenderSummary(&out, tabs, Options{Format: FormatJSON}))
+	var report summaryReport
+	assert.NoError(t, json.Unmarshal(out.Bytes(), &report))
+	assert.True(t, report.SummaryOnly)
+	assert.Len(t, report.Tabs, 2)
+	assert.Equal(t, v1alpha1.FLAKY_STATUS, report.Tabs[1].State)
+
+	assert.ErrorContains(t, RenderSummary(&out, tabs, Options{Format: FormatJUnit}), "no summary-only report")
+}
+
+func TestFindings(t *testing.T) {
+	findings := Findings(sampleTabs(), Options{})
+	assert.Len(t, findings, 3)
+	overall := findings[1]
+	assert.Equal(t, "sig-release-master-blocking", overall.Dashboard)
+	assert.Equal(t, "build-master", overall.Tab)
+	assert.Equal(t, 3, overall.FailureCount)
+	assert.Equal(t, 0.75, overall.FailureRate)
+	assert.Equal(t, "critical", overall.Severity)
+	// 1 flake in 4 runs
+	assert.Equal(t, "medium", findings[0].Severity)
+}
+
+func TestRenderFindingsOptionalFieldsUnset(t *testing.T) {
+	// only the identity of the test is set, no runs, URLs, SIGs, bugs or durations
+	tabs := []*v1alpha1.DashboardTab{{
+		DashboardName: "sig-release-master-blocking",
+		TabName:       "build-master",
+		TestRuns:      []v1alpha1.TestResult{{TestName: "Overall"}},
+	}}
+	for _, format := range Formats {
+		for _, groupBy := range GroupBys {
+			t.Run(format+"/"+groupBy, func(t *testing.T) {
+				var buf bytes.Buffer
+				assert.NoError(t, Render(&buf, tabs, Options{Format: format, GroupBy: groupBy, ShowHistory: 5, Template: "{{.TestName}} {{.ProwJobURL}}"}))
+				assert.Contains(t, buf.String(), "Overall")
+				assert.NotContains(t, buf.String(), "<nil>")
+			})
+		}
+	}
+
+	// a zero finding renders as well
+	var buf bytes.Buffer
+	assert.NoError(t, renderJUnit(&buf, []Finding{{}}))
+	assert.NoError(t, renderJSON(&buf, groupRows([]Finding{{}}, GroupBySIG), GroupBySIG))
+	assert.NoError(t, renderTable(&buf, groupRows([]Finding{{}}, GroupByDashboard), true, true))
+	assert.NoError(t, renderMarkdown(&buf, groupRows([]Finding{{}}, GroupByNone), true))
+}
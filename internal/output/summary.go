@@ -0,0 +1,75 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"sigs.k8s.io/signalhound/api/v1alpha1"
+)
+
+// summaryLabel heads the summary-only reports, so they are not mistaken for
+// a report where the tabs have no failing test.
+const summaryLabel = "SUMMARY ONLY: tab states from the TestGrid dashboard summaries, tests not fetched"
+
+// summaryRow is a tab of the summary-only report
+type summaryRow struct {
+	Dashboard     string `json:"dashboard"`
+	Tab           string `json:"tab"`
+	State         string `json:"state"`
+	TabURL        string `json:"tab_url"`
+	LastUpdated   int64  `json:"last_updated,omitempty"`
+	StatusMessage string `json:"status_message,omitempty"`
+}
+
+// summaryReport is the JSON summary-only report
+type summaryReport struct {
+	SummaryOnly bool         `json:"summary_only"`
+	Tabs        []summaryRow `json:"tabs"`
+}
+
+// RenderSummary writes the state of each tab, fetched without its tests, in
+// the table, json or markdown format, labeled as a summary-only report.
+func RenderSummary(w io.Writer, tabs []*v1alpha1.DashboardTab, opts Options) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+	rows := make([]summaryRow, 0, len(tabs))
+	for _, tab := range tabs {
+		rows = append(rows, summaryRow{
+			Dashboard:     tab.DashboardName,
+			Tab:           tab.TabName,
+			State:         tab.TabState,
+			TabURL:        tab.TabURL,
+			LastUpdated:   tab.LastUpdated,
+			StatusMessage: tab.StatusMessage,
+		})
+	}
+
+	switch opts.Format {
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(summaryReport{SummaryOnly: true, Tabs: rows})
+	case FormatJUnit, FormatTemplate:
+		return fmt.Errorf("the %s format has no summary-only report, use one of: %s, %s, %s", opts.Format, FormatTable, FormatJSON, FormatMarkdown)
+	case FormatMarkdown:
+		fmt.Fprintf(w, "_%s._\n\n", summaryLabel)
+		fmt.Fprintln(w, "| State | Dashboard | Tab | Status |")
+		fmt.Fprintln(w, "|-------|-----------|-----|--------|")
+		for _, r := range rows {
+			fmt.Fprintf(w, "| %s | %s | [%s](%s) | %s |\n", r.State, r.Dashboard, r.Tab, r.TabURL, strings.ReplaceAll(r.StatusMessage, "|", "\\|"))
+		}
+		return nil
+	default:
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintf(tw, "== %s ==\n", summaryLabel)
+		fmt.Fprintf(tw, "%s\tDASHBOARD\tTAB\tSTATUS\n", colorState("STATE", opts.Color))
+		for _, r := range rows {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", colorState(r.State, opts.Color), r.Dashboard, r.Tab, r.StatusMessage)
+		}
+		return tw.Flush()
+	}
+}
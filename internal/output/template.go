@@ -0,0 +1,60 @@
+package output
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+)
+
+// templateEscapes are the escape sequences of a template written on the
+// command line, where a shell passes them as is.
+var templateEscapes = strings.NewReplacer(`\t`, "\t", `\n`, "\n")
+
+// templateFuncs are the functions of the template format, besides the ones of
+// text/template.
+var templateFuncs = template.FuncMap{
+	"join": strings.Join,
+}
+
+// parseTemplate parses the template of the template format, after replacing
+// its \t and \n escapes, and executes it once on an empty Finding so unknown
+// fields fail before scraping.
+func parseTemplate(text string) (*template.Template, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, errors.New("the template output format requires a template")
+	}
+	tmpl, err := template.New("output").Funcs(templateFuncs).Parse(templateEscapes.Replace(text))
+	if err != nil {
+		return nil, fmt.Errorf("invalid output template: %w", err)
+	}
+	if err := tmpl.Execute(io.Discard, Finding{}); err != nil {
+		return nil, fmt.Errorf("invalid output template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// renderTemplate writes each finding through the template, one per line: a
+// newline is added when the rendered finding does not end with one.
+func renderTemplate(w io.Writer, findings []Finding, text string) error {
+	tmpl, err := parseTemplate(text)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	for _, finding := range findings {
+		buf.Reset()
+		if err := tmpl.Execute(&buf, finding); err != nil {
+			return fmt.Errorf("failed to render %s/%s: %w", finding.Tab, finding.TestName, err)
+		}
+		if !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+			buf.WriteByte('\n')
+		}
+		if _, err := w.Write(buf.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/signalhound/api/v1alpha1"
+)
+
+// nameLayout is the layout of the snapshot file names, in UTC, sorting in
+// time order.
+const nameLayout = "20060102T150405Z"
+
+// Ext is the extension of the snapshot files.
+const Ext = ".json"
+
+// Snapshot is the result of a scrape saved for later comparisons
+type Snapshot struct {
+	// Time is when the scrape finished.
+	Time time.Time `json:"time"`
+	// Dashboards are the scraped dashboards, including the clean ones.
+	Dashboards []string `json:"dashboards"`
+	// Tabs are the failing and flaky tabs with their tests.
+	Tabs []*v1alpha1.DashboardTab `json:"tabs"`
+}
+
+// FileName returns the name of the snapshot file taken at the time.
+func FileName(t time.Time) string {
+	return t.UTC().Format(nameLayout) + Ext
+}
+
+// Write saves the snapshot to the directory, created when missing, in a file
+// named after its time. The file is written atomically through a temporary
+// file of the same directory renamed over it, and its path returned.
+func Write(dir string, snapshot Snapshot) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, FileName(snapshot.Time))
+	file, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return "", err
+	}
+	// the temporary file is left behind only when the rename fails
+	defer os.Remove(file.Name()) // nolint:errcheck
+	if _, err = file.Write(append(data, '\n')); err != nil {
+		file.Close() // nolint:errcheck
+		return "", err
+	}
+	if err = file.Close(); err != nil {
+		return "", err
+	}
+	return path, os.Rename(file.Name(), path)
+}
+
+// Read returns the snapshot of the file.
+func Read(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var snapshot Snapshot
+	if err = json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("invalid snapshot %s: %w", path, err)
+	}
+	return &snapshot, nil
+}
+
+// List returns the paths of the snapshot files of the directory, the oldest
+// first. The files not named like a snapshot are ignored.
+func List(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, Ext) {
+			continue
+		}
+		if _, err := time.Parse(nameLayout, strings.TrimSuffix(name, Ext)); err != nil {
+			continue
+		}
+		paths = append(paths, filepath.Join(dir, name))
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// Prune removes the oldest snapshots of the directory beyond the keep most
+// recent ones, returning the removed paths. Nothing is removed when keep is 0.
+func Prune(dir string, keep int) ([]string, error) {
+	if keep <= 0 {
+		return nil, nil
+	}
+	paths, err := List(dir)
+	if err != nil || len(paths) <= keep {
+		return nil, err
+	}
+	pruned := paths[:len(paths)-keep]
+	for i, path := range pruned {
+		if err := os.Remove(path); err != nil {
+			return pruned[:i], err
+		}
+	}
+	return pruned, nil
+}
@@ -0,0 +1,67 @@
+package snapshot
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"sigs.k8s.io/signalhound/api/v1alpha1"
+)
+
+func TestFileName(t *testing.T) {
+	local := time.Date(2025, 10, 1, 14, 30, 5, 0, time.FixedZone("CEST", 2*60*60))
+	assert.Equal(t, "20251001T123005Z.json", FileName(local))
+}
+
+func TestWriteRead(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "snapshots")
+	snapshot := Snapshot{
+		Time:       time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC),
+		Dashboards: []string{"sig-release-master-blocking"},
+		Tabs: []*v1alpha1.DashboardTab{{
+			DashboardName: "sig-release-master-blocking",
+			TabName:       "build-master",
+			TestRuns:      []v1alpha1.TestResult{{TestName: "Overall", FailureCount: 2}},
+		}},
+	}
+	path, err := Write(dir, snapshot)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "20251001T120000Z.json"), path)
+
+	read, err := Read(path)
+	assert.NoError(t, err)
+	assert.Equal(t, snapshot.Dashboards, read.Dashboards)
+	assert.True(t, snapshot.Time.Equal(read.Time))
+	assert.Equal(t, "Overall", read.Tabs[0].TestRuns[0].TestName)
+
+	assert.NoError(t, os.WriteFile(path, []byte("{"), 0o644))
+	_, err = Read(path)
+	assert.ErrorContains(t, err, "invalid snapshot")
+}
+
+func TestPrune(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	for day := 0; day < 4; day++ {
+		_, err := Write(dir, Snapshot{Time: start.AddDate(0, 0, day)})
+		assert.NoError(t, err)
+	}
+	// files not named like a snapshot are left alone
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "notes.json"), []byte("{}"), 0o644))
+
+	pruned, err := Prune(dir, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, pruned)
+
+	pruned, err = Prune(dir, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "20251001T000000Z.json"), filepath.Join(dir, "20251002T000000Z.json")}, pruned)
+
+	paths, err := List(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "20251003T000000Z.json"), filepath.Join(dir, "20251004T000000Z.json")}, paths)
+	assert.FileExists(t, filepath.Join(dir, "notes.json"))
+}
@@ -0,0 +1,176 @@
+package snapshot
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	DirectionWorsening = "worsening"
+	DirectionNew       = "new"
+	DirectionStable    = "stable"
+	DirectionImproving = "improving"
+	DirectionRecovered = "recovered"
+)
+
+// Directions are the directions of a test trend, the most actionable first
+var Directions = []string{DirectionWorsening, DirectionNew, DirectionStable, DirectionImproving, DirectionRecovered}
+
+// trendLevels are the characters of the ASCII trend, from the lowest to the
+// highest failure and flake count of the test
+const trendLevels = "_-=#"
+
+// trendMissing is the ASCII trend character of a snapshot not reporting the test.
+const trendMissing = '.'
+
+// Point is the failure and flake counts of a test in a snapshot
+type Point struct {
+	Time     time.Time `json:"time"`
+	Reported bool      `json:"reported"`
+	Failures int       `json:"failures"`
+	Flakes   int       `json:"flakes"`
+}
+
+// Trend is how the failure and flake counts of a test evolved over the snapshots
+type Trend struct {
+	Key       string  `json:"key"`
+	Dashboard string  `json:"dashboard"`
+	Tab       string  `json:"tab"`
+	Test      string  `json:"test"`
+	Direction string  `json:"direction"`
+	Points    []Point `json:"points"`
+}
+
+// Load reads the snapshots of the directory, the oldest first.
+func Load(dir string) ([]*Snapshot, error) {
+	paths, err := List(dir)
+	if err != nil {
+		return nil, err
+	}
+	snapshots := make([]*Snapshot, 0, len(paths))
+	for _, path := range paths {
+		snapshot, err := Read(path)
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots, nil
+}
+
+// Trends returns the trend of every test reported in one of the snapshots,
+// given the oldest first, sorted by direction then by key.
+func Trends(snapshots []*Snapshot) []Trend {
+	byKey := map[string]*Trend{}
+	for i, snapshot := range snapshots {
+		for _, tab := range snapshot.Tabs {
+			for j := range tab.TestRuns {
+				test := &tab.TestRuns[j]
+				key := tab.TestKey(test)
+				trend, ok := byKey[key]
+				if !ok {
+					trend = &Trend{Key: key, Dashboard: tab.DashboardName, Tab: tab.TabName, Test: test.TestName, Points: make([]Point, len(snapshots))}
+					for k, s := range snapshots {
+						trend.Points[k].Time = s.Time
+					}
+					byKey[key] = trend
+				}
+				point := &trend.Points[i]
+				point.Reported = true
+				point.Failures += test.FailureCount
+				point.Flakes += test.FlakeCount
+			}
+		}
+	}
+
+	trends := make([]Trend, 0, len(byKey))
+	for _, trend := range byKey {
+		trend.Direction = direction(trend.Points)
+		trends = append(trends, *trend)
+	}
+	order := make(map[string]int, len(Directions))
+	for i, direction := range Directions {
+		order[direction] = i
+	}
+	sort.Slice(trends, func(i, j int) bool {
+		if trends[i].Direction != trends[j].Direction {
+			return order[trends[i].Direction] < order[trends[j].Direction]
+		}
+		return trends[i].Key < trends[j].Key
+	})
+	return trends
+}
+
+// FirstSeen returns, by test key, when each test of the most recent snapshot
+// was first reported: the time of the oldest snapshot of the unbroken run of
+// snapshots reporting it up to the most recent one. The snapshots are given
+// the oldest first.
+func FirstSeen(snapshots []*Snapshot) map[string]time.Time {
+	if len(snapshots) == 0 {
+		return nil
+	}
+	reported := func(snapshot *Snapshot) map[string]bool {
+		keys := map[string]bool{}
+		for _, tab := range snapshot.Tabs {
+			for i := range tab.TestRuns {
+				keys[tab.TestKey(&tab.TestRuns[i])] = true
+			}
+		}
+		return keys
+	}
+	active := reported(snapshots[len(snapshots)-1])
+	firstSeen := make(map[string]time.Time, len(active))
+	for i := len(snapshots) - 1; i >= 0 && len(active) > 0; i-- {
+		keys := reported(snapshots[i])
+		for key := range active {
+			if !keys[key] {
+				delete(active, key)
+				continue
+			}
+			firstSeen[key] = snapshots[i].Time
+		}
+	}
+	return firstSeen
+}
+
+// direction compares the first and the last snapshots: a test only reported
+// in the last one is new, one not reported anymore recovered, otherwise its
+// failure and flake count is improving, worsening or stable.
+func direction(points []Point) string {
+	first, last := points[0], points[len(points)-1]
+	switch {
+	case !last.Reported:
+		return DirectionRecovered
+	case !first.Reported:
+		return DirectionNew
+	case last.Failures+last.Flakes > first.Failures+first.Flakes:
+		return DirectionWorsening
+	case last.Failures+last.Flakes < first.Failures+first.Flakes:
+		return DirectionImproving
+	}
+	return DirectionStable
+}
+
+// ASCII renders the failure and flake count of each snapshot, the oldest on
+// the left, scaled to the highest count of the test, with a dot for the
+// snapshots not reporting the test.
+func (t Trend) ASCII() string {
+	highest := 0
+	for _, point := range t.Points {
+		highest = max(highest, point.Failures+point.Flakes)
+	}
+	var b strings.Builder
+	for _, point := range t.Points {
+		count := point.Failures + point.Flakes
+		switch {
+		case !point.Reported:
+			b.WriteByte(trendMissing)
+		case highest == 0:
+			b.WriteByte(trendLevels[0])
+		default:
+			b.WriteByte(trendLevels[count*(len(trendLevels)-1)/highest])
+		}
+	}
+	return b.String()
+}
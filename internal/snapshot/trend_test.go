@@ -0,0 +1,82 @@
+package snapshot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"sigs.k8s.io/signalhound/api/v1alpha1"
+)
+
+// snapshotOf returns a snapshot of the build-master tab with the failure and
+// flake counts by test name
+func snapshotOf(day int, counts map[string][2]int) *Snapshot {
+	tab := &v1alpha1.DashboardTab{DashboardName: "sig-release-master-blocking", TabName: "build-master"}
+	for name, count := range counts {
+		tab.TestRuns = append(tab.TestRuns, v1alpha1.TestResult{TestName: name, FailureCount: count[0], FlakeCount: count[1]})
+	}
+	return &Snapshot{Time: time.Date(2025, 10, day, 0, 0, 0, 0, time.UTC), Tabs: []*v1alpha1.DashboardTab{tab}}
+}
+
+func TestTrends(t *testing.T) {
+	snapshots := []*Snapshot{
+		snapshotOf(1, map[string][2]int{"worse": {1, 0}, "better": {4, 2}, "same": {2, 0}, "fixed": {3, 0}}),
+		snapshotOf(2, map[string][2]int{"worse": {2, 1}, "better": {3, 0}, "same": {1, 1}}),
+		snapshotOf(3, map[string][2]int{"worse": {4, 0}, "better": {1, 0}, "same": {2, 0}, "appeared": {1, 0}}),
+	}
+	trends := Trends(snapshots)
+
+	var got [][2]string
+	for _, trend := range trends {
+		got = append(got, [2]string{trend.Test, trend.Direction})
+	}
+	assert.Equal(t, [][2]string{
+		{"worse", DirectionWorsening},
+		{"appeared", DirectionNew},
+		{"same", DirectionStable},
+		{"better", DirectionImproving},
+		{"fixed", DirectionRecovered},
+	}, got)
+
+	worse := trends[0]
+	assert.Equal(t, "sig-release-master-blocking/build-master/worse", worse.Key)
+	assert.Equal(t, []Point{
+		{Time: snapshots[0].Time, Reported: true, Failures: 1},
+		{Time: snapshots[1].Time, Reported: true, Failures: 2, Flakes: 1},
+		{Time: snapshots[2].Time, Reported: true, Failures: 4},
+	}, worse.Points)
+}
+
+func TestTrendASCII(t *testing.T) {
+	tests := []struct {
+		name     string
+		points   []Point
+		expected string
+	}{
+		{name: "scaled", points: []Point{{Reported: true, Failures: 1}, {Reported: true, Failures: 3, Flakes: 3}, {Reported: true, Flakes: 4}}, expected: "_#="},
+		{name: "not reported", points: []Point{{}, {Reported: true, Failures: 2}, {}}, expected: ".#."},
+		{name: "no counts", points: []Point{{Reported: true}}, expected: "_"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Trend{Points: tt.points}.ASCII())
+		})
+	}
+}
+
+func TestFirstSeen(t *testing.T) {
+	snapshots := []*Snapshot{
+		snapshotOf(1, map[string][2]int{"long": {1, 0}, "back": {2, 0}, "fixed": {1, 0}}),
+		snapshotOf(2, map[string][2]int{"long": {1, 0}}),
+		snapshotOf(3, map[string][2]int{"long": {2, 0}, "back": {1, 0}, "new": {1, 0}}),
+	}
+	key := func(test string) string { return "sig-release-master-blocking/build-master/" + test }
+	assert.Equal(t, map[string]time.Time{
+		key("long"): snapshots[0].Time,
+		// a test failing again is seen from its latest streak
+		key("back"): snapshots[2].Time,
+		key("new"):  snapshots[2].Time,
+	}, FirstSeen(snapshots))
+	assert.Nil(t, FirstSeen(nil))
+}
@@ -0,0 +1,140 @@
+package testgrid
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/signalhound/api/v1alpha1"
+)
+
+// ackDateLayout is the layout of the expiry date of an acknowledgment.
+const ackDateLayout = "2006-01-02"
+
+// AckEntry is a known-broken test of an ack file, muted from the reports, the
+// TUI and the draft issues until it expires
+type AckEntry struct {
+	// Key is the test key, <dashboard>/<tab>/<test name>, see v1alpha1.TestKey.
+	Key string `json:"key"`
+	// Expires is the last day the test is acknowledged, like 2025-10-01,
+	// empty never expires.
+	Expires string `json:"expires,omitempty"`
+	// Reason tells why the test is muted, e.g. the issue tracking it.
+	Reason string `json:"reason,omitempty"`
+}
+
+// Acknowledgments are the acknowledged tests by test key with the time their
+// acknowledgment expires, zero when it never does
+type Acknowledgments map[string]time.Time
+
+// AckKey returns the test key of an acknowledgment normalized like
+// v1alpha1.TestKey, so a test name copied from TestGrid matches.
+func AckKey(key string) string {
+	parts := strings.SplitN(strings.TrimSpace(key), "/", 3)
+	if len(parts) != 3 {
+		return strings.TrimSpace(key)
+	}
+	return v1alpha1.TestKey(parts[0], parts[1], parts[2])
+}
+
+// ReadAckFile returns the entries of the ack file, a JSON list of AckEntry.
+func ReadAckFile(path string) ([]AckEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var entries []AckEntry
+	if err = json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("invalid ack file %s: %w", path, err)
+	}
+	return entries, nil
+}
+
+// WriteAckFile writes the entries sorted by key to the ack file, atomically
+// through a temporary file of the same directory renamed over it.
+func WriteAckFile(path string, entries []AckEntry) error {
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	// the temporary file is left behind only when the rename fails
+	defer os.Remove(file.Name()) // nolint:errcheck
+	if _, err = file.Write(append(data, '\n')); err != nil {
+		file.Close() // nolint:errcheck
+		return err
+	}
+	if err = file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
+
+// NewAcknowledgments returns the acknowledgments of the entries, an entry
+// expires at the end of its expiry day in UTC.
+func NewAcknowledgments(entries []AckEntry) (Acknowledgments, error) {
+	acks := make(Acknowledgments, len(entries))
+	for _, entry := range entries {
+		key := AckKey(entry.Key)
+		if key == "" {
+			return nil, errors.New("acknowledgment without test key")
+		}
+		expires, err := ParseAckExpiry(entry.Expires)
+		if err != nil {
+			return nil, fmt.Errorf("acknowledgment of %s: %w", entry.Key, err)
+		}
+		acks[key] = expires
+	}
+	return acks, nil
+}
+
+// ParseAckExpiry returns the end of the expiry day in UTC, zero for an empty
+// day that never expires.
+func ParseAckExpiry(day string) (time.Time, error) {
+	if day == "" {
+		return time.Time{}, nil
+	}
+	parsed, err := time.Parse(ackDateLayout, day)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expires on %q, expected a date like %s", day, ackDateLayout)
+	}
+	return parsed.AddDate(0, 0, 1), nil
+}
+
+// Acknowledged reports whether the test key is acknowledged at the time.
+func (a Acknowledgments) Acknowledged(key string, now time.Time) bool {
+	expires, ok := a[key]
+	return ok && (expires.IsZero() || now.Before(expires))
+}
+
+// Expired returns the keys of the acknowledgments expired at the time, sorted.
+func (a Acknowledgments) Expired(now time.Time) (expired []string) {
+	for key, expires := range a {
+		if !expires.IsZero() && !now.Before(expires) {
+			expired = append(expired, key)
+		}
+	}
+	sort.Strings(expired)
+	return expired
+}
+
+// excludeAcknowledged leaves out the acknowledged tests of the tab, returning
+// the kept tests and the number left out.
+func excludeAcknowledged(tab *v1alpha1.DashboardTab, tests []v1alpha1.TestResult, acks Acknowledgments, now time.Time) ([]v1alpha1.TestResult, int) {
+	kept := tests[:0]
+	for i := range tests {
+		if !acks.Acknowledged(tab.TestKey(&tests[i]), now) {
+			kept = append(kept, tests[i])
+		}
+	}
+	return kept, len(tests) - len(kept)
+}
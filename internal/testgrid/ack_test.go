@@ -0,0 +1,70 @@
+package testgrid
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"sigs.k8s.io/signalhound/api/v1alpha1"
+)
+
+func TestAckKey(t *testing.T) {
+	assert.Equal(t, "sig-release-master-blocking/gce-cos-master-default/kubetest.up",
+		AckKey(" sig-release-master-blocking/gce-cos-master-default/Kubetest.Up (3 failures) "))
+	assert.Equal(t, "sig-release-master-blocking/build-master/e2e [sig-node] pods/exec",
+		AckKey("sig-release-master-blocking/build-master/E2E [sig-node]  Pods/exec"))
+	assert.Equal(t, "not-a-key", AckKey("not-a-key"))
+}
+
+func TestNewAcknowledgments(t *testing.T) {
+	acks, err := NewAcknowledgments([]AckEntry{
+		{Key: "sig-release-master-blocking/build-master/Overall"},
+		{Key: "sig-release-master-blocking/build-master/kubetest.up", Expires: "2025-10-01", Reason: "kubernetes/kubernetes#1"},
+	})
+	assert.NoError(t, err)
+
+	day := time.Date(2025, 10, 1, 23, 59, 0, 0, time.UTC)
+	assert.True(t, acks.Acknowledged("sig-release-master-blocking/build-master/overall", day.AddDate(5, 0, 0)))
+	assert.True(t, acks.Acknowledged("sig-release-master-blocking/build-master/kubetest.up", day))
+	assert.False(t, acks.Acknowledged("sig-release-master-blocking/build-master/kubetest.up", day.Add(time.Minute)))
+	assert.False(t, acks.Acknowledged("sig-release-master-blocking/build-master/other", day))
+	assert.Equal(t, []string{"sig-release-master-blocking/build-master/kubetest.up"}, acks.Expired(day.Add(time.Minute)))
+	assert.Empty(t, acks.Expired(day))
+
+	_, err = NewAcknowledgments([]AckEntry{{Key: "a/b/c", Expires: "10/01/2025"}})
+	assert.ErrorContains(t, err, "expected a date like 2006-01-02")
+	_, err = NewAcknowledgments([]AckEntry{{Key: " "}})
+	assert.ErrorContains(t, err, "without test key")
+}
+
+func TestAckFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "acks.json")
+	entries := []AckEntry{{Key: "b/tab/test", Expires: "2025-10-01"}, {Key: "a/tab/test", Reason: "tracked"}}
+	assert.NoError(t, WriteAckFile(path, entries))
+
+	read, err := ReadAckFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []AckEntry{{Key: "a/tab/test", Reason: "tracked"}, {Key: "b/tab/test", Expires: "2025-10-01"}}, read)
+
+	assert.NoError(t, os.WriteFile(path, []byte("a/tab/test"), 0o644))
+	_, err = ReadAckFile(path)
+	assert.ErrorContains(t, err, "invalid ack file")
+	_, err = ReadAckFile(filepath.Join(t.TempDir(), "missing.json"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestExcludeAcknowledged(t *testing.T) {
+	tab := &v1alpha1.DashboardTab{DashboardName: "sig-release-master-blocking", TabName: "build-master"}
+	tests := []v1alpha1.TestResult{{TestName: "Overall"}, {TestName: "kubetest.Up"}, {TestName: "kubetest.Down"}}
+	acks := Acknowledgments{
+		"sig-release-master-blocking/build-master/kubetest.up":   {},
+		"sig-release-master-blocking/build-master/kubetest.down": time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	kept, acknowledged := excludeAcknowledged(tab, tests, acks, time.Date(2025, 10, 2, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, 1, acknowledged)
+	assert.Equal(t, []v1alpha1.TestResult{{TestName: "Overall"}, {TestName: "kubetest.Down"}}, kept)
+}
@@ -0,0 +1,159 @@
+package testgrid
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/signalhound/api/v1alpha1"
+)
+
+const fixtureDashboard = "sig-release-master-blocking"
+
+// newFakeTestGrid serves the recorded TestGrid responses of the fixtures folder,
+// <dashboard>/summary.json for the summary and <dashboard>/<tab>.json for the
+// tab tables, unknown paths are not found.
+func newFakeTestGrid(t *testing.T, fixtures string) *TestGrid {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		dashboard, endpoint, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+		var fixture string
+		switch endpoint {
+		case "summary":
+			fixture = filepath.Join(fixtures, dashboard, "summary.json")
+		case "table":
+			fixture = filepath.Join(fixtures, dashboard, r.URL.Query().Get("tab")+".json")
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		data, err := os.ReadFile(fixture)
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data) // nolint
+	}))
+	t.Cleanup(server.Close)
+	return NewTestGrid(server.URL)
+}
+
+func TestSummarizeFixtures(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     FilterOptions
+		expected map[string][]string
+		states   map[string]string
+	}{
+		{
+			name: "no thresholds",
+			expected: map[string][]string{
+				fixtureDashboard + "#build-master": {"ci-kubernetes-build.Overall", "ci-kubernetes-build.Push"},
+				fixtureDashboard + "#gce-cos-master-default": {
+					"Kubernetes e2e suite.[It] [sig-node] Pods should be restarted with a liveness probe",
+					"Kubernetes e2e suite.[It] [sig-network] Services should serve endpoints on same port",
+				},
+			},
+			states: map[string]string{
+				fixtureDashboard + "#build-master":           v1alpha1.FAILING_STATUS,
+				fixtureDashboard + "#gce-cos-master-default": v1alpha1.FLAKY_STATUS,
+			},
+		},
+		{
+			name: "failure and flake thresholds",
+			opts: FilterOptions{MinFailure: 2, MinFlake: 2},
+			expected: map[string][]string{
+				fixtureDashboard + "#build-master": {"ci-kubernetes-build.Overall"},
+				fixtureDashboard + "#gce-cos-master-default": {
+					"Kubernetes e2e suite.[It] [sig-node] Pods should be restarted with a liveness probe",
+				},
+			},
+		},
+		{
+			name:     "thresholds above every test",
+			opts:     FilterOptions{MinFailure: 5, MinFlake: 5},
+			expected: map[string][]string{},
+		},
+		{
+			name: "passing tabs included",
+			opts: FilterOptions{MinFailure: 2, MinFlake: 2, IncludePassing: true},
+			expected: map[string][]string{
+				fixtureDashboard + "#build-master": {"ci-kubernetes-build.Overall", "ci-kubernetes-build.Push"},
+				fixtureDashboard + "#gce-cos-master-default": {
+					"Kubernetes e2e suite.[It] [sig-node] Pods should be restarted with a liveness probe",
+					"Kubernetes e2e suite.[It] [sig-network] Services should serve endpoints on same port",
+				},
+				fixtureDashboard + "#verify-master": {"verify.gofmt"},
+			},
+		},
+	}
+
+	tg := newFakeTestGrid(t, "testdata")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tabs, err := tg.Summarize([]string{fixtureDashboard}, tt.opts, nil)
+			assert.NoError(t, err)
+
+			actual := map[string][]string{}
+			for _, tab := range tabs {
+				assert.Equal(t, fixtureDashboard, tab.DashboardName)
+				if state, ok := tt.states[tab.BoardHash]; ok {
+					assert.Equal(t, state, tab.TabState)
+				}
+				for _, test := range tab.TestRuns {
+					actual[tab.BoardHash] = append(actual[tab.BoardHash], test.TestName)
+				}
+			}
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestSummarizeFixturesResults(t *testing.T) {
+	tabs, err := newFakeTestGrid(t, "testdata").Summarize([]string{fixtureDashboard}, FilterOptions{}, nil)
+	assert.NoError(t, err)
+	sort.Slice(tabs, func(i, j int) bool { return tabs[i].BoardHash < tabs[j].BoardHash })
+	assert.Len(t, tabs, 2)
+	assert.Equal(t, int64(1758999500000), tabs[0].LastUpdated)
+	assert.Equal(t, "2 of 3 (66.7%) recent columns passed (12 of 15 or 80.0% cells)", tabs[0].StatusMessage)
+
+	build := tabs[0].TestRuns[0]
+	assert.Equal(t, "https://prow.k8s.io/view/gs/kubernetes-ci-logs/logs/ci-kubernetes-build/1972011571991285762", build.ProwJobURL)
+	assert.Equal(t, 2, build.FailureCount)
+	assert.Equal(t, 3, build.TotalRuns)
+	assert.Equal(t, int64(1758999193000), build.LatestTimestamp)
+	assert.Equal(t, int64(1758985000000), build.FirstTimestamp)
+	assert.Contains(t, build.ErrorMessage, "Build failed")
+
+	flake := tabs[1].TestRuns[0]
+	assert.Equal(t, 2, flake.FlakeCount)
+	assert.Equal(t, 0.5, flake.FlakeRate())
+	assert.Contains(t, flake.TriageURL, "job=ci-kubernetes-e2e-gci-gce$&test=Pods%20should%20be%20restarted%20with%20a%20liveness%20probe")
+}
+
+func TestSummarizeFixturesLimitPerTab(t *testing.T) {
+	tabs, err := newFakeTestGrid(t, "testdata").Summarize([]string{fixtureDashboard}, FilterOptions{LimitPerTab: 1}, nil)
+	assert.NoError(t, err)
+	sort.Slice(tabs, func(i, j int) bool { return tabs[i].BoardHash < tabs[j].BoardHash })
+	assert.Len(t, tabs[0].TestRuns, 1)
+	assert.Equal(t, 1, tabs[0].HiddenTests)
+	assert.Equal(t, 2, FailingTests(tabs[0]))
+	assert.True(t, Widespread(tabs[0], 2))
+}
+
+func TestSummarizeFixturesMissingDashboard(t *testing.T) {
+	tabs, err := newFakeTestGrid(t, "testdata").Summarize([]string{"sig-release-missing", fixtureDashboard}, FilterOptions{}, nil)
+	assert.ErrorIs(t, err, ErrDashboardNotFound)
+	assert.Len(t, tabs, 2)
+
+	dashboardErrs := DashboardErrors(err)
+	assert.Len(t, dashboardErrs, 1)
+	assert.Equal(t, "sig-release-missing", dashboardErrs[0].Dashboard)
+	assert.Equal(t, http.StatusNotFound, dashboardErrs[0].StatusCode)
+}
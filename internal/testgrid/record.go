@@ -0,0 +1,129 @@
+package testgrid
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// redacted replaces the values of the sensitive headers in the recorded metadata.
+const redacted = "REDACTED"
+
+// sensitiveHeaders are scrubbed from the recorded request and response headers.
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// recordMeta is saved next to each recorded response body
+type recordMeta struct {
+	URL            string      `json:"url"`
+	StatusCode     int         `json:"status_code"`
+	RequestHeader  http.Header `json:"request_header"`
+	ResponseHeader http.Header `json:"response_header"`
+}
+
+// recordTransport writes every raw TestGrid response to the record folder,
+// with the same layout served by NewReplayTransport.
+type recordTransport struct {
+	base http.RoundTripper
+	dir  string
+}
+
+func (r *recordTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	response, err := r.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close() // nolint:errcheck
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	response.Body = io.NopCloser(bytes.NewReader(body))
+
+	path := filepath.Join(r.dir, recordPath(req))
+	meta, err := json.MarshalIndent(recordMeta{
+		URL:            req.URL.Redacted(),
+		StatusCode:     response.StatusCode,
+		RequestHeader:  scrubHeader(req.Header),
+		ResponseHeader: scrubHeader(response.Header),
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("error recording %s: %w", req.URL.Path, err)
+	}
+	if err = os.WriteFile(path, body, 0o644); err != nil {
+		return nil, fmt.Errorf("error recording %s: %w", req.URL.Path, err)
+	}
+	if err = os.WriteFile(path+".meta", meta, 0o644); err != nil {
+		return nil, fmt.Errorf("error recording %s: %w", req.URL.Path, err)
+	}
+	return response, nil
+}
+
+// replayTransport serves the responses saved by a recordTransport
+type replayTransport struct {
+	dir string
+}
+
+// NewReplayTransport returns a transport serving the TestGrid responses recorded
+// with ClientOptions.RecordDir, requests without a recording are not found.
+func NewReplayTransport(dir string) http.RoundTripper {
+	return &replayTransport{dir: dir}
+}
+
+func (r *replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	path := filepath.Join(r.dir, recordPath(req))
+	response := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Request:    req,
+	}
+
+	body, err := os.ReadFile(path)
+	if err != nil {
+		response.StatusCode = http.StatusNotFound
+		body = []byte(fmt.Sprintf("no recording for %s", req.URL.Path))
+	} else if data, err := os.ReadFile(path + ".meta"); err == nil {
+		var meta recordMeta
+		if err = json.Unmarshal(data, &meta); err != nil {
+			return nil, fmt.Errorf("error parsing recording metadata %s: %w", path, err)
+		}
+		response.StatusCode = meta.StatusCode
+		response.Header = meta.ResponseHeader
+	}
+	response.Status = fmt.Sprintf("%d %s", response.StatusCode, http.StatusText(response.StatusCode))
+	response.Body = io.NopCloser(bytes.NewReader(body))
+	response.ContentLength = int64(len(body))
+	return response, nil
+}
+
+// recordPath returns the file of a request in the record folder, the summary
+// is saved in <dashboard>/summary.json and tab tables in <dashboard>/<tab>.json.
+func recordPath(req *http.Request) string {
+	dashboard, endpoint, _ := strings.Cut(strings.Trim(req.URL.Path, "/"), "/")
+	switch endpoint {
+	case "summary":
+		return filepath.Join(dashboard, "summary.json")
+	case "table":
+		return filepath.Join(dashboard, strings.ReplaceAll(req.URL.Query().Get("tab"), "/", "_")+".json")
+	}
+	return strings.ReplaceAll(strings.Trim(req.URL.Path, "/"), "/", "_") + ".json"
+}
+
+// scrubHeader returns a copy of the header with the sensitive values redacted.
+func scrubHeader(header http.Header) http.Header {
+	scrubbed := header.Clone()
+	for _, name := range sensitiveHeaders {
+		if scrubbed.Get(name) != "" {
+			scrubbed.Set(name, redacted)
+		}
+	}
+	return scrubbed
+}
@@ -0,0 +1,82 @@
+package testgrid
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/signalhound/api/v1alpha1"
+)
+
+func sortedTabs(tabs []*v1alpha1.DashboardTab) []*v1alpha1.DashboardTab {
+	sort.Slice(tabs, func(i, j int) bool { return tabs[i].BoardHash < tabs[j].BoardHash })
+	return tabs
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	recordDir := t.TempDir()
+	live := newFakeTestGrid(t, "testdata")
+	client, err := NewHTTPClient(ClientOptions{
+		Timeout:      time.Second,
+		MaxIdleConns: 1,
+		KeepAlive:    time.Second,
+		Token:        "secret-token",
+		RecordDir:    recordDir,
+	})
+	assert.NoError(t, err)
+
+	recorded, err := NewTestGridWithClient(live.URL, client).Summarize([]string{fixtureDashboard}, FilterOptions{}, nil)
+	assert.NoError(t, err)
+	assert.FileExists(t, filepath.Join(recordDir, fixtureDashboard, "summary.json"))
+	assert.FileExists(t, filepath.Join(recordDir, fixtureDashboard, "build-master.json"))
+
+	// credentials are never written to the recorded metadata
+	data, err := os.ReadFile(filepath.Join(recordDir, fixtureDashboard, "summary.json.meta"))
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "secret-token")
+	var meta recordMeta
+	assert.NoError(t, json.Unmarshal(data, &meta))
+	assert.Equal(t, redacted, meta.RequestHeader.Get("Authorization"))
+	assert.Equal(t, http.StatusOK, meta.StatusCode)
+
+	// the replay serves the same responses without reaching TestGrid
+	replay := NewTestGridWithClient(live.URL, &http.Client{Transport: NewReplayTransport(recordDir)})
+	replayed, err := replay.Summarize([]string{fixtureDashboard}, FilterOptions{}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, sortedTabs(recorded), sortedTabs(replayed))
+}
+
+func TestReplayMissingRecording(t *testing.T) {
+	replay := NewTestGridWithClient(URL, &http.Client{Transport: NewReplayTransport(t.TempDir())})
+	_, err := replay.FetchTabSummary(fixtureDashboard, v1alpha1.ERROR_STATUSES)
+	assert.ErrorIs(t, err, ErrDashboardNotFound)
+}
+
+func TestRecordPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      string
+		expected string
+	}{
+		{name: "summary", url: "https://testgrid.k8s.io/sig-release-master-blocking/summary", expected: "sig-release-master-blocking/summary.json"},
+		{
+			name:     "tab table",
+			url:      "https://testgrid.k8s.io/sig-release-master-blocking/table?tab=build-master&exclude-non-failed-tests=&dashboard=sig-release-master-blocking",
+			expected: "sig-release-master-blocking/build-master.json",
+		},
+		{name: "other endpoint", url: "https://testgrid.k8s.io/api/v1/dashboards", expected: "api_v1_dashboards.json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, filepath.FromSlash(tt.expected), recordPath(req))
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package testgrid
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/signalhound/api/v1alpha1"
+)
+
+const (
+	SeverityLow      = "low"
+	SeverityMedium   = "medium"
+	SeverityHigh     = "high"
+	SeverityCritical = "critical"
+)
+
+// SeverityLevels are the severity levels of a test, the least severe first.
+var SeverityLevels = []string{SeverityLow, SeverityMedium, SeverityHigh, SeverityCritical}
+
+const (
+	// criticalFailureRate is the failure rate of a test in a failing tab from
+	// which it is critical, failing most of its runs.
+	criticalFailureRate = 0.5
+	// mediumFlakeRate is the flake rate of a test in a flaky tab from which
+	// its flakes are medium rather than low.
+	mediumFlakeRate = 0.2
+)
+
+// ParseSeverity parses a severity level ignoring case, empty is returned for
+// an empty value.
+func ParseSeverity(value string) (string, error) {
+	level := strings.ToLower(strings.TrimSpace(value))
+	if level != "" && !slices.Contains(SeverityLevels, level) {
+		return "", fmt.Errorf("unknown severity %q, valid values are: %s", value, strings.Join(SeverityLevels, ", "))
+	}
+	return level, nil
+}
+
+// SeverityLevel classifies a test in a tab state: a test of a failing tab is
+// critical when it fails at least half of its runs, high otherwise, one of a
+// flaky tab medium when it flakes at least a fifth of its runs, low otherwise.
+func SeverityLevel(state string, test *v1alpha1.TestResult) string {
+	switch state {
+	case v1alpha1.FAILING_STATUS:
+		if test.TotalRuns > 0 && float64(test.FailureCount)/float64(test.TotalRuns) >= criticalFailureRate {
+			return SeverityCritical
+		}
+		return SeverityHigh
+	case v1alpha1.FLAKY_STATUS:
+		if test.TotalRuns > 0 && float64(test.FlakeCount)/float64(test.TotalRuns) >= mediumFlakeRate {
+			return SeverityMedium
+		}
+	}
+	return SeverityLow
+}
+
+// AtLeastSeverity reports whether the level is at least as severe as the
+// minimum level, an empty minimum accepts every level.
+func AtLeastSeverity(level, minLevel string) bool {
+	return minLevel == "" || slices.Index(SeverityLevels, level) >= slices.Index(SeverityLevels, minLevel)
+}
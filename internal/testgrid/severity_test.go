@@ -0,0 +1,51 @@
+package testgrid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"sigs.k8s.io/signalhound/api/v1alpha1"
+)
+
+func TestParseSeverity(t *testing.T) {
+	level, err := ParseSeverity(" High")
+	assert.NoError(t, err)
+	assert.Equal(t, SeverityHigh, level)
+
+	level, err = ParseSeverity("")
+	assert.NoError(t, err)
+	assert.Empty(t, level)
+
+	_, err = ParseSeverity("urgent")
+	assert.ErrorContains(t, err, "valid values are: low, medium, high, critical")
+}
+
+func TestSeverityLevel(t *testing.T) {
+	tests := []struct {
+		name     string
+		state    string
+		test     v1alpha1.TestResult
+		expected string
+	}{
+		{name: "failing most runs", state: v1alpha1.FAILING_STATUS, test: v1alpha1.TestResult{FailureCount: 5, TotalRuns: 10}, expected: SeverityCritical},
+		{name: "failing some runs", state: v1alpha1.FAILING_STATUS, test: v1alpha1.TestResult{FailureCount: 2, TotalRuns: 10}, expected: SeverityHigh},
+		{name: "failing without runs", state: v1alpha1.FAILING_STATUS, test: v1alpha1.TestResult{FailureCount: 2}, expected: SeverityHigh},
+		{name: "flaking often", state: v1alpha1.FLAKY_STATUS, test: v1alpha1.TestResult{FlakeCount: 2, TotalRuns: 10}, expected: SeverityMedium},
+		{name: "flaking rarely", state: v1alpha1.FLAKY_STATUS, test: v1alpha1.TestResult{FlakeCount: 1, TotalRuns: 10}, expected: SeverityLow},
+		{name: "passing", state: v1alpha1.PASSING_STATUS, test: v1alpha1.TestResult{TotalRuns: 10}, expected: SeverityLow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, SeverityLevel(tt.state, &tt.test))
+		})
+	}
+}
+
+func TestAtLeastSeverity(t *testing.T) {
+	assert.True(t, AtLeastSeverity(SeverityLow, ""))
+	assert.True(t, AtLeastSeverity(SeverityCritical, SeverityHigh))
+	assert.True(t, AtLeastSeverity(SeverityHigh, SeverityHigh))
+	assert.False(t, AtLeastSeverity(SeverityMedium, SeverityHigh))
+}
@@ -0,0 +1,115 @@
+package testgrid
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/signalhound/api/v1alpha1"
+)
+
+const (
+	SortFailures   = "failures"
+	SortFlakes     = "flakes"
+	SortName       = "name"
+	SortSeverity   = "severity"
+	SortSIG        = "sig"
+	SortDuration   = "duration"
+	SortRegression = "regression"
+)
+
+// SortKeys are the keys accepted by ParseSort.
+var SortKeys = []string{SortFailures, SortFlakes, SortName, SortSeverity, SortSIG, SortDuration, SortRegression}
+
+// Sort orders the tabs and their tests returned by Summarize
+type Sort struct {
+	// Key is one of SortKeys, empty keeps the fetch order.
+	Key string
+	// Descending reverses the order of the key, ties are always sorted by name.
+	Descending bool
+}
+
+// NeedsDurations reports whether the sort key orders the tests by their
+// durations, which are only fetched on demand.
+func (s Sort) NeedsDurations() bool {
+	return s.Key == SortDuration || s.Key == SortRegression
+}
+
+// ParseSort parses a sort key, with an optional "-" prefix for descending, e.g. "-failures".
+func ParseSort(value string) (Sort, error) {
+	if value == "" {
+		return Sort{}, nil
+	}
+	sort := Sort{Key: strings.TrimPrefix(value, "-"), Descending: strings.HasPrefix(value, "-")}
+	if !slices.Contains(SortKeys, sort.Key) {
+		return Sort{}, fmt.Errorf("unknown sort %q, valid values are: %s, with an optional - prefix for descending",
+			value, strings.Join(SortKeys, ", "))
+	}
+	return sort, nil
+}
+
+// Severity scores a test in a tab state by its SeverityLevel, so the sort
+// agrees with the filing severity, and the tests of the same level by their
+// failures and flakes.
+func Severity(state string, test *v1alpha1.TestResult) int {
+	return slices.Index(SeverityLevels, SeverityLevel(state, test))<<16 + test.FailureCount + test.FlakeCount
+}
+
+// firstSIG returns the first SIG of the test, tests without SIG are sorted last.
+func firstSIG(test *v1alpha1.TestResult) string {
+	if sigs := ExtractSIGs(test.TestName); len(sigs) > 0 {
+		return sigs[0]
+	}
+	return "\uffff"
+}
+
+// compare orders two tests by the sort key, then by name.
+func (s Sort) compare(stateA string, a *v1alpha1.TestResult, stateB string, b *v1alpha1.TestResult) int {
+	var result int
+	switch s.Key {
+	case SortFailures:
+		result = cmp.Compare(a.FailureCount, b.FailureCount)
+	case SortFlakes:
+		result = cmp.Compare(a.FlakeCount, b.FlakeCount)
+	case SortSeverity:
+		result = cmp.Compare(Severity(stateA, a), Severity(stateB, b))
+	case SortSIG:
+		result = cmp.Compare(firstSIG(a), firstSIG(b))
+	case SortName:
+		result = cmp.Compare(a.TestName, b.TestName)
+	case SortDuration:
+		result = cmp.Compare(a.DurationSeconds, b.DurationSeconds)
+	case SortRegression:
+		result = cmp.Compare(a.DurationRatio(), b.DurationRatio())
+	}
+	if s.Descending {
+		result = -result
+	}
+	if result == 0 {
+		result = cmp.Compare(a.TestName, b.TestName)
+	}
+	return result
+}
+
+// Apply sorts the tests of each tab, then the tabs by their first test, ties
+// are sorted by name so the order is deterministic across runs.
+func (s Sort) Apply(tabs []*v1alpha1.DashboardTab) {
+	if s.Key == "" {
+		return
+	}
+	for _, tab := range tabs {
+		slices.SortStableFunc(tab.TestRuns, func(a, b v1alpha1.TestResult) int {
+			return s.compare(tab.TabState, &a, tab.TabState, &b)
+		})
+	}
+	slices.SortStableFunc(tabs, func(a, b *v1alpha1.DashboardTab) int {
+		if len(a.TestRuns) == 0 || len(b.TestRuns) == 0 {
+			return cmp.Compare(len(b.TestRuns), len(a.TestRuns))
+		}
+		if result := s.compare(a.TabState, &a.TestRuns[0], b.TabState, &b.TestRuns[0]); result != 0 {
+			return result
+		}
+		return cmp.Compare(a.BoardHash, b.BoardHash)
+	})
+}
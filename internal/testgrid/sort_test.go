@@ -0,0 +1,162 @@
+package testgrid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/signalhound/api/v1alpha1"
+)
+
+func TestParseSort(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected Sort
+		wantErr  bool
+	}{
+		{name: "fetch order", value: ""},
+		{name: "ascending", value: "name", expected: Sort{Key: SortName}},
+		{name: "descending", value: "-failures", expected: Sort{Key: SortFailures, Descending: true}},
+		{name: "slowest first", value: "-duration", expected: Sort{Key: SortDuration, Descending: true}},
+		{name: "unknown key", value: "-age", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sort, err := ParseSort(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, sort)
+		})
+	}
+}
+
+func sortFixture() []*v1alpha1.DashboardTab {
+	return []*v1alpha1.DashboardTab{
+		{
+			BoardHash: "blocking#flaky",
+			TabState:  v1alpha1.FLAKY_STATUS,
+			TestRuns: []v1alpha1.TestResult{
+				{TestName: "[sig-node] b", FlakeCount: 3},
+				{TestName: "[sig-apps] a", FlakeCount: 1},
+			},
+		},
+		{
+			BoardHash: "blocking#failing",
+			TabState:  v1alpha1.FAILING_STATUS,
+			TestRuns: []v1alpha1.TestResult{
+				{TestName: "d", FailureCount: 1},
+				{TestName: "c", FailureCount: 1},
+				{TestName: "[sig-node] e", FailureCount: 2},
+			},
+		},
+	}
+}
+
+func TestSortApply(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected map[string][]string
+		tabs     []string
+	}{
+		{
+			name:  "fetch order",
+			value: "",
+			tabs:  []string{"blocking#flaky", "blocking#failing"},
+			expected: map[string][]string{
+				"blocking#flaky":   {"[sig-node] b", "[sig-apps] a"},
+				"blocking#failing": {"d", "c", "[sig-node] e"},
+			},
+		},
+		{
+			name:  "most failures first, ties by name",
+			value: "-failures",
+			tabs:  []string{"blocking#failing", "blocking#flaky"},
+			expected: map[string][]string{
+				"blocking#flaky":   {"[sig-apps] a", "[sig-node] b"},
+				"blocking#failing": {"[sig-node] e", "c", "d"},
+			},
+		},
+		{
+			name:  "most severe first",
+			value: "-severity",
+			tabs:  []string{"blocking#failing", "blocking#flaky"},
+			expected: map[string][]string{
+				"blocking#flaky":   {"[sig-node] b", "[sig-apps] a"},
+				"blocking#failing": {"[sig-node] e", "c", "d"},
+			},
+		},
+		{
+			name:  "by sig, tests without sig last",
+			value: "sig",
+			tabs:  []string{"blocking#flaky", "blocking#failing"},
+			expected: map[string][]string{
+				"blocking#flaky":   {"[sig-apps] a", "[sig-node] b"},
+				"blocking#failing": {"[sig-node] e", "c", "d"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sort, err := ParseSort(tt.value)
+			assert.NoError(t, err)
+			tabs := sortFixture()
+			sort.Apply(tabs)
+
+			var hashes []string
+			for _, tab := range tabs {
+				hashes = append(hashes, tab.BoardHash)
+				var names []string
+				for _, test := range tab.TestRuns {
+					names = append(names, test.TestName)
+				}
+				assert.Equal(t, tt.expected[tab.BoardHash], names)
+			}
+			assert.Equal(t, tt.tabs, hashes)
+		})
+	}
+}
+
+func TestSortDurations(t *testing.T) {
+	tabs := []*v1alpha1.DashboardTab{{
+		BoardHash: "blocking#slow",
+		TestRuns: []v1alpha1.TestResult{
+			{TestName: "steady", DurationSeconds: 3000, BaselineDurationSeconds: 2900},
+			{TestName: "regressed", DurationSeconds: 1200, BaselineDurationSeconds: 300},
+			{TestName: "untimed"},
+		},
+	}}
+	names := func() (names []string) {
+		for _, test := range tabs[0].TestRuns {
+			names = append(names, test.TestName)
+		}
+		return names
+	}
+
+	Sort{Key: SortDuration, Descending: true}.Apply(tabs)
+	assert.Equal(t, []string{"steady", "regressed", "untimed"}, names())
+	Sort{Key: SortRegression, Descending: true}.Apply(tabs)
+	assert.Equal(t, []string{"regressed", "steady", "untimed"}, names())
+	assert.True(t, Sort{Key: SortRegression}.NeedsDurations())
+	assert.False(t, Sort{Key: SortFailures}.NeedsDurations())
+}
+
+func TestSeverity(t *testing.T) {
+	failing := Severity(v1alpha1.FAILING_STATUS, &v1alpha1.TestResult{FailureCount: 1})
+	flaky := Severity(v1alpha1.FLAKY_STATUS, &v1alpha1.TestResult{FlakeCount: 10})
+	assert.Greater(t, failing, flaky)
+	assert.Greater(t, Severity(v1alpha1.FLAKY_STATUS, &v1alpha1.TestResult{FlakeCount: 11}), flaky)
+
+	// a critical test, failing most of its runs, ranks above a high one with
+	// more failures
+	critical := Severity(v1alpha1.FAILING_STATUS, &v1alpha1.TestResult{FailureCount: 3, TotalRuns: 4})
+	high := Severity(v1alpha1.FAILING_STATUS, &v1alpha1.TestResult{FailureCount: 4, TotalRuns: 20})
+	assert.Greater(t, critical, high)
+	assert.Greater(t, Severity(v1alpha1.FLAKY_STATUS, &v1alpha1.TestResult{FlakeCount: 3, TotalRuns: 10}),
+		Severity(v1alpha1.FLAKY_STATUS, &v1alpha1.TestResult{FlakeCount: 4, TotalRuns: 40}))
+}
@@ -1,15 +1,27 @@
 package testgrid
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
+	"net"
 	"net/http"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"sigs.k8s.io/signalhound/api/v1alpha1"
-	"sigs.k8s.io/signalhound/internal/prow"
+	"sigs.k8s.io/signalhound/internal/version"
 )
 
 var (
@@ -17,8 +29,127 @@ var (
 	e2eSuitePrefix = `Kubernetes e2e suite.`
 	kubetestPrefix = `kubetest`
 	testRegex      = e2eSuitePrefix + `\[It\] \[(\w.*)\] (?<TEST>\w.*)`
+
+	// testNamePattern is compiled once, matching every e2e test of a tab
+	testNamePattern = regexp.MustCompile(testRegex)
+
+	// sigPattern matches the SIG labels of a test name, e.g. [sig-node]
+	sigPattern = regexp.MustCompile(`\[sig-([\w-]+)\]`)
 )
 
+// ErrTestGridUnavailable is returned when TestGrid can not be reached or fails to answer.
+var ErrTestGridUnavailable = errors.New("testgrid unavailable")
+
+// ErrScrapeTimeout is returned for the dashboards and tabs not fetched before
+// the ScrapeTimeout of Summarize.
+var ErrScrapeTimeout = errors.New("scrape deadline exceeded")
+
+// ErrDashboardNotFound is returned for a dashboard TestGrid does not serve, e.g.
+// after it was renamed or removed.
+var ErrDashboardNotFound = errors.New("dashboard not found")
+
+// DashboardError is the error of a dashboard TestGrid answered with a 404 or a
+// permanent redirect for, the other dashboards are still scraped.
+type DashboardError struct {
+	Dashboard  string
+	StatusCode int
+	// Location is the target of the permanent redirect, empty on a 404.
+	Location string
+}
+
+func (e *DashboardError) Error() string {
+	if e.Location != "" {
+		return fmt.Sprintf("dashboard %s not found (renamed?): permanently redirected to %s", e.Dashboard, e.Location)
+	}
+	return fmt.Sprintf("dashboard %s not found (renamed?): %d %s", e.Dashboard, e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+func (e *DashboardError) Unwrap() error {
+	return ErrDashboardNotFound
+}
+
+// DashboardErrors returns the dashboard errors joined in the error of
+// Summarize, one per dashboard not found.
+func DashboardErrors(err error) (dashboardErrs []*DashboardError) {
+	var dashboardErr *DashboardError
+	switch e := err.(type) {
+	case interface{ Unwrap() []error }:
+		for _, joined := range e.Unwrap() {
+			dashboardErrs = append(dashboardErrs, DashboardErrors(joined)...)
+		}
+	default:
+		if errors.As(err, &dashboardErr) {
+			dashboardErrs = append(dashboardErrs, dashboardErr)
+		}
+	}
+	return dashboardErrs
+}
+
+// ScrapeError is the error of a dashboard, or of one of its tabs, failing to
+// be fetched, e.g. TestGrid timing out, worth retrying unlike a DashboardError.
+type ScrapeError struct {
+	Dashboard string
+	// Tab is the failed tab, empty when the summary of the dashboard failed.
+	Tab string
+	Err error
+}
+
+func (e *ScrapeError) Error() string {
+	if e.Tab != "" {
+		return fmt.Sprintf("dashboard %s tab %s: %v", e.Dashboard, e.Tab, e.Err)
+	}
+	return fmt.Sprintf("dashboard %s: %v", e.Dashboard, e.Err)
+}
+
+func (e *ScrapeError) Unwrap() error {
+	return e.Err
+}
+
+// FailedDashboards returns the dashboards with a scrape error joined in the
+// error of Summarize, once each in the order of the errors.
+func FailedDashboards(err error) (dashboards []string) {
+	var scrapeErr *ScrapeError
+	switch e := err.(type) {
+	case interface{ Unwrap() []error }:
+		for _, joined := range e.Unwrap() {
+			for _, dashboard := range FailedDashboards(joined) {
+				if !slices.Contains(dashboards, dashboard) {
+					dashboards = append(dashboards, dashboard)
+				}
+			}
+		}
+	default:
+		if errors.As(err, &scrapeErr) {
+			dashboards = append(dashboards, scrapeErr.Dashboard)
+		}
+	}
+	return dashboards
+}
+
+// stopPermanentRedirect is the redirect policy of the summary requests, a
+// permanent redirect is returned as is to report the dashboard as renamed.
+func stopPermanentRedirect(next func(*http.Request, []*http.Request) error) func(*http.Request, []*http.Request) error {
+	return func(req *http.Request, via []*http.Request) error {
+		if req.Response != nil && isPermanentRedirect(req.Response.StatusCode) {
+			return http.ErrUseLastResponse
+		}
+		if next != nil {
+			return next(req, via)
+		}
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		return nil
+	}
+}
+
+func isPermanentRedirect(status int) bool {
+	return status == http.StatusMovedPermanently || status == http.StatusPermanentRedirect
+}
+
+// DefaultDashboards are the release dashboards scraped by default.
+var DefaultDashboards = []string{"sig-release-master-blocking", "sig-release-master-informing"}
+
 const tabURL = "%s/%s/table?tab=%s&exclude-non-failed-tests=&dashboard=%s"
 
 // TestGroup serializes the content from testgrid tab endpoint
@@ -47,6 +178,71 @@ type Test struct {
 	ShortTexts   []string   `json:"short_texts"`
 	Statuses     []Statuses `json:"statuses"`
 	Target       string     `json:"target"`
+	// Alert is the alert state of a test failing past the tab threshold, null otherwise.
+	Alert json.RawMessage `json:"alert"`
+	// LinkedBugs are the bugs annotated on the test in TestGrid.
+	LinkedBugs []string `json:"linked_bugs"`
+	// Graphs are the metrics of the test requested with graph-metrics, e.g.
+	// the durations of its runs.
+	Graphs []Graph `json:"graphs"`
+}
+
+// Graph holds the values of the metrics of a test, one per column, newest
+// first, a column without value is 0.
+type Graph struct {
+	Metric []string    `json:"metric"`
+	Values [][]float64 `json:"values"`
+}
+
+// durationMetric is the TestGrid metric of the test durations, in minutes.
+const durationMetric = "test-duration-minutes"
+
+// minBaselineRuns is the minimum number of older runs with a duration needed
+// to compute the baseline duration of a test.
+const minBaselineRuns = 3
+
+// durations returns the durations of the runs of the test in minutes, newest
+// first, nil when TestGrid has no timing data.
+func (t *Test) durations() []float64 {
+	for _, graph := range t.Graphs {
+		for i, metric := range graph.Metric {
+			if metric == durationMetric && i < len(graph.Values) {
+				return graph.Values[i]
+			}
+		}
+	}
+	return nil
+}
+
+// durationStats returns the duration of the newest run with timing data and
+// the median duration of the older runs, both in seconds. The baseline is 0
+// with less than minBaselineRuns older runs.
+func (t *Test) durationStats() (latest, baseline int64) {
+	var runs []float64
+	for _, minutes := range t.durations() {
+		if minutes > 0 {
+			runs = append(runs, minutes)
+		}
+	}
+	if len(runs) == 0 {
+		return 0, 0
+	}
+	latest = int64(runs[0] * 60)
+	older := slices.Clone(runs[1:])
+	if len(older) < minBaselineRuns {
+		return latest, 0
+	}
+	slices.Sort(older)
+	median := older[len(older)/2]
+	if len(older)%2 == 0 {
+		median = (older[len(older)/2-1] + median) / 2
+	}
+	return latest, int64(median * 60)
+}
+
+// alerting returns whether TestGrid raised an alert for the test.
+func (t *Test) alerting() bool {
+	return len(t.Alert) > 0 && string(t.Alert) != "null"
 }
 
 type Statuses struct {
@@ -54,11 +250,25 @@ type Statuses struct {
 	Value int `json:"value"`
 }
 
+// TestGrid status values of a test column, see the TestStatus enum of the TestGrid API.
+const (
+	statusNoResult       = 0
+	statusPass           = 1
+	statusPassWithErrors = 2
+	statusPassWithSkips  = 3
+	statusRunning        = 4
+	statusFlaky          = 13
+	statusBuildPassed    = 15
+)
+
+// MaxRunResults is the maximum number of recent run results kept for each test.
+const MaxRunResults = 50
+
 // RenderStatuses renders the statuses of a test into a string.
 func (te *Test) RenderStatuses(timestamps []int64) (string, int, int) {
 	var firstFailureIndex = -1
 	var failureCount = 0
-	var output strings.Builder
+	var output []byte
 
 	for i, shortText := range te.ShortTexts {
 		if shortText == "" {
@@ -69,32 +279,414 @@ func (te *Test) RenderStatuses(timestamps []int64) (string, int, int) {
 			firstFailureIndex = i
 		}
 
-		formattedStatus := formatTestStatus(shortText, timestamps[i], te.Messages[i])
-		output.WriteString(formattedStatus)
+		output = appendTestStatus(output, shortText, timestamps[i], te.Messages[i])
 		failureCount++
 	}
 
-	return output.String(), failureCount, firstFailureIndex
+	return string(output), failureCount, firstFailureIndex
+}
+
+// failureStats counts the failures and returns the first failure index, same
+// as RenderStatuses without rendering the messages.
+func (te *Test) failureStats() (failureCount, firstFailureIndex int) {
+	firstFailureIndex = -1
+	for i, shortText := range te.ShortTexts {
+		if shortText == "" {
+			continue
+		}
+		if firstFailureIndex < 0 {
+			firstFailureIndex = i
+		}
+		failureCount++
+	}
+	return failureCount, firstFailureIndex
+}
+
+// runCount returns the number of runs with a result, from the run-length encoded
+// statuses, falling back to the number of columns when they are missing.
+func (te *Test) runCount() (runs int) {
+	if len(te.Statuses) == 0 {
+		return len(te.ShortTexts)
+	}
+	for _, status := range te.Statuses {
+		if status.Value != statusNoResult {
+			runs += status.Count
+		}
+	}
+	return runs
 }
 
+// runResults returns the results of the most recent runs, newest first and up
+// to MaxRunResults, from the run-length encoded statuses. Without statuses a
+// run with a short text is a failure.
+func (te *Test) runResults(timestamps []int64) []v1alpha1.RunResult {
+	size := min(len(timestamps), MaxRunResults)
+	results := make([]v1alpha1.RunResult, 0, size)
+	if len(te.Statuses) == 0 {
+		for i := 0; i < size && i < len(te.ShortTexts); i++ {
+			result := v1alpha1.RUN_PASSED
+			if te.ShortTexts[i] != "" {
+				result = v1alpha1.RUN_FAILED
+			}
+			results = append(results, v1alpha1.RunResult{Timestamp: timestamps[i], Result: result})
+		}
+		return results
+	}
+	for _, status := range te.Statuses {
+		for range status.Count {
+			if len(results) == size {
+				return results
+			}
+			results = append(results, v1alpha1.RunResult{Timestamp: timestamps[len(results)], Result: runResult(status.Value)})
+		}
+	}
+	return results
+}
+
+// anchorAt drops the columns older than the run, matched by its build ID in
+// the changelists or column IDs, so the counts start from that run. It reports
+// false and keeps every column when the tab has no such run.
+func (tg *TestGroup) anchorAt(runID string) bool {
+	column := slices.Index(tg.Changelists, runID)
+	if column < 0 {
+		column = slices.Index(tg.ColumnIds, runID)
+	}
+	if column < 0 {
+		return false
+	}
+	columns := column + 1
+	tg.Changelists = firstColumns(tg.Changelists, columns)
+	tg.ColumnIds = firstColumns(tg.ColumnIds, columns)
+	tg.Timestamps = firstColumns(tg.Timestamps, columns)
+	for i := range tg.Tests {
+		tg.Tests[i].truncate(columns)
+	}
+	return true
+}
+
+// truncate keeps the newest columns of the test, including its run-length
+// encoded statuses.
+func (te *Test) truncate(columns int) {
+	te.ShortTexts = firstColumns(te.ShortTexts, columns)
+	te.Messages = firstColumns(te.Messages, columns)
+	for i := range te.Graphs {
+		for j := range te.Graphs[i].Values {
+			te.Graphs[i].Values[j] = firstColumns(te.Graphs[i].Values[j], columns)
+		}
+	}
+	statuses := make([]Statuses, 0, len(te.Statuses))
+	for _, status := range te.Statuses {
+		if columns == 0 {
+			break
+		}
+		status.Count = min(status.Count, columns)
+		columns -= status.Count
+		statuses = append(statuses, status)
+	}
+	te.Statuses = statuses
+}
+
+// firstColumns returns at most the first columns of a column slice.
+func firstColumns[T any](values []T, columns int) []T {
+	return values[:min(columns, len(values))]
+}
+
+// runResult maps a TestGrid status value to the result of a run.
+func runResult(value int) string {
+	switch value {
+	case statusNoResult, statusRunning:
+		return v1alpha1.RUN_NO_RESULT
+	case statusPass, statusPassWithErrors, statusPassWithSkips, statusBuildPassed:
+		return v1alpha1.RUN_PASSED
+	case statusFlaky:
+		return v1alpha1.RUN_FLAKY
+	}
+	return v1alpha1.RUN_FAILED
+}
+
+// TestGridClient fetches the dashboards from TestGrid, implemented by TestGrid
+// and replaced by fakes in tests.
+type TestGridClient interface {
+	// ListDashboards returns the names of every dashboard of TestGrid.
+	ListDashboards() ([]string, error)
+	// FetchTabSummary returns the tabs of the dashboard in one of the statuses.
+	FetchTabSummary(dashboard string, filterStatus []string) ([]v1alpha1.DashboardSummary, error)
+	// FetchTabTests returns the tab of the summary with its filtered tests.
+	FetchTabTests(summary *v1alpha1.DashboardSummary, opts FilterOptions) (*v1alpha1.DashboardTab, error)
+	// Summarize returns the tabs with tests left after filtering of every dashboard.
+	Summarize(dashboards []string, opts FilterOptions, progress chan<- ProgressEvent) ([]*v1alpha1.DashboardTab, error)
+}
+
+var _ TestGridClient = &TestGrid{}
+
+// DefaultConcurrency is the default number of tabs fetched in parallel.
+const DefaultConcurrency = 8
+
 type TestGrid struct {
-	URL string
+	URL    string
+	Client *http.Client
+	// Concurrency is the number of tabs of a dashboard fetched in parallel by
+	// Summarize, values below 1 fetch them one at a time.
+	Concurrency int
+	// RequestTimeout cancels a single request to TestGrid, failing only its
+	// dashboard or tab, 0 leaves the timeout to the client.
+	RequestTimeout time.Duration
+	// ScrapeTimeout is the deadline of a Summarize call, the dashboards and
+	// tabs not fetched in time fail with ErrScrapeTimeout, 0 disables it.
+	ScrapeTimeout time.Duration
+	// Context is the parent of every request, canceling it aborts the
+	// requests in flight and the rest of the scrape. Defaults to
+	// context.Background.
+	Context context.Context
+}
+
+// context returns the parent context of the requests.
+func (t *TestGrid) context() context.Context {
+	if t.Context == nil {
+		return context.Background()
+	}
+	return t.Context
+}
+
+// get sends a GET request with the client, canceled after the RequestTimeout
+// or with the context. The returned cancel function releases the request
+// once its body is read.
+func (t *TestGrid) get(ctx context.Context, client *http.Client, url string) (*http.Response, context.CancelFunc, error) {
+	cancel := context.CancelFunc(func() {})
+	if t.RequestTimeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, t.RequestTimeout)
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+	return response, cancel, nil
+}
+
+// ClientOptions configures the HTTP client and transport used to reach TestGrid
+type ClientOptions struct {
+	// Timeout is the time limit for a single request, including reading the body.
+	Timeout time.Duration
+	// MaxIdleConns is the maximum number of idle keep-alive connections kept in the pool.
+	MaxIdleConns int
+	// KeepAlive is the interval between keep-alive probes of an active connection.
+	KeepAlive time.Duration
+	// Token is an optional bearer token sent to authenticated TestGrid mirrors.
+	Token string
+	// BasicAuth is an optional "user:password" pair sent to authenticated TestGrid mirrors.
+	BasicAuth string
+	// RecordDir is an optional folder where every raw TestGrid response is written,
+	// to be served back by NewReplayTransport.
+	RecordDir string
+	// CACert is an optional PEM bundle of certificate authorities trusted in
+	// addition to the system ones, e.g. the CA of a TLS inspecting proxy.
+	CACert string
+	// UserAgent is the User-Agent header of every request, the Go default when empty.
+	UserAgent string
+}
+
+// String renders the options with the credentials masked.
+func (o ClientOptions) String() string {
+	return fmt.Sprintf("{Timeout:%s MaxIdleConns:%d KeepAlive:%s Token:%s BasicAuth:%s RecordDir:%s CACert:%s UserAgent:%s}",
+		o.Timeout, o.MaxIdleConns, o.KeepAlive, maskCredential(o.Token), maskCredential(o.BasicAuth), o.RecordDir, o.CACert, o.UserAgent)
+}
+
+// maskCredential hides a secret value, keeping only whether it was set.
+func maskCredential(credential string) string {
+	if credential == "" {
+		return ""
+	}
+	return "****"
+}
+
+// authTransport sets the Authorization header on every request sent to TestGrid.
+type authTransport struct {
+	base          http.RoundTripper
+	authorization string
+}
+
+func (a *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", a.authorization)
+	return a.base.RoundTrip(req)
+}
+
+// userAgentTransport sets the User-Agent header on every request.
+type userAgentTransport struct {
+	base      http.RoundTripper
+	userAgent string
+}
+
+func (u *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", u.userAgent)
+	return u.base.RoundTrip(req)
+}
+
+// WithUserAgent wraps the transport to send the User-Agent on every request,
+// the transport is returned as is for an empty User-Agent.
+func WithUserAgent(base http.RoundTripper, userAgent string) http.RoundTripper {
+	if userAgent == "" {
+		return base
+	}
+	return &userAgentTransport{base: base, userAgent: userAgent}
+}
+
+// DefaultClientOptions are used by NewTestGrid when no client is provided.
+var DefaultClientOptions = ClientOptions{
+	Timeout:      30 * time.Second,
+	MaxIdleConns: 100,
+	KeepAlive:    30 * time.Second,
+	UserAgent:    version.UserAgent(),
+}
+
+// Validate returns an error if any of the client options is not positive.
+func (o ClientOptions) Validate() error {
+	if o.Timeout <= 0 {
+		return fmt.Errorf("http timeout must be positive, got %s", o.Timeout)
+	}
+	if o.MaxIdleConns <= 0 {
+		return fmt.Errorf("max idle connections must be positive, got %d", o.MaxIdleConns)
+	}
+	if o.KeepAlive <= 0 {
+		return fmt.Errorf("keepalive must be positive, got %s", o.KeepAlive)
+	}
+	if o.Token != "" && o.BasicAuth != "" {
+		return errors.New("testgrid token and basic auth are mutually exclusive")
+	}
+	if user, _, found := strings.Cut(o.BasicAuth, ":"); o.BasicAuth != "" && (!found || user == "") {
+		return errors.New("testgrid basic auth must be in the user:password format")
+	}
+	return nil
+}
+
+// NewHTTPClient builds the HTTP client with a pooled transport from the options.
+func NewHTTPClient(opts ClientOptions) (*http.Client, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{
+		Timeout:   opts.Timeout,
+		KeepAlive: opts.KeepAlive,
+	}).DialContext
+	transport.MaxIdleConns = opts.MaxIdleConns
+	// all requests hit the same TestGrid host, so the pool is per host.
+	transport.MaxIdleConnsPerHost = opts.MaxIdleConns
+	if opts.CACert != "" {
+		tlsConfig, err := CATLSConfig(opts.CACert)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	// the recorder is the innermost transport, so it sees the requests as sent
+	var base http.RoundTripper = transport
+	if opts.RecordDir != "" {
+		base = &recordTransport{base: transport, dir: opts.RecordDir}
+	}
+	base = WithUserAgent(base, opts.UserAgent)
+
+	client := &http.Client{Timeout: opts.Timeout, Transport: base}
+	switch {
+	case opts.Token != "":
+		client.Transport = &authTransport{base: base, authorization: "Bearer " + opts.Token}
+	case opts.BasicAuth != "":
+		encoded := base64.StdEncoding.EncodeToString([]byte(opts.BasicAuth))
+		client.Transport = &authTransport{base: base, authorization: "Basic " + encoded}
+	}
+	return client, nil
+}
+
+// CATLSConfig returns the TLS configuration trusting the certificate
+// authorities of the PEM bundle, appended to the system pool.
+func CATLSConfig(path string) (*tls.Config, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading the CA bundle: %w", err)
+	}
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no PEM certificate found in the CA bundle %s", path)
+	}
+	return &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}, nil
 }
 
 func NewTestGrid(url string) *TestGrid {
-	return &TestGrid{URL: url}
+	client, _ := NewHTTPClient(DefaultClientOptions)
+	return NewTestGridWithClient(url, client)
+}
+
+// NewTestGridWithClient returns a TestGrid using a custom HTTP client.
+func NewTestGridWithClient(url string, client *http.Client) *TestGrid {
+	return &TestGrid{URL: url, Client: client, Concurrency: DefaultConcurrency}
 }
 
 type DashboardMapper map[string]*v1alpha1.DashboardSummary
 
+// ListDashboards returns the names of every dashboard of TestGrid, sorted, from
+// the dashboards listing of the TestGrid API.
+func (t *TestGrid) ListDashboards() ([]string, error) {
+	response, cancel, err := t.get(t.context(), t.Client, t.URL+"/api/v1/dashboards")
+	if err != nil {
+		return nil, fmt.Errorf("%w: error listing testgrid dashboards: %w", ErrTestGridUnavailable, err)
+	}
+	defer cancel()
+	defer response.Body.Close() // nolint:errcheck
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: dashboards listing returned %s", ErrTestGridUnavailable, response.Status)
+	}
+
+	var listing struct {
+		Dashboards []struct {
+			Name string `json:"name"`
+		} `json:"dashboards"`
+	}
+	if err = json.NewDecoder(response.Body).Decode(&listing); err != nil {
+		return nil, fmt.Errorf("error unmarshaling dashboards listing: %v", err)
+	}
+	names := make([]string, 0, len(listing.Dashboards))
+	for _, dashboard := range listing.Dashboards {
+		names = append(names, dashboard.Name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
 // FetchTabSummary retrieves the summary data for a given dashboard from the TestGrid
-func (t *TestGrid) FetchTabSummary(dashboard string, filterStatus []string) (summary []v1alpha1.DashboardSummary, err error) {
+func (t *TestGrid) FetchTabSummary(dashboard string, filterStatus []string) ([]v1alpha1.DashboardSummary, error) {
+	return t.fetchTabSummary(t.context(), dashboard, filterStatus)
+}
+
+func (t *TestGrid) fetchTabSummary(ctx context.Context, dashboard string, filterStatus []string) (summary []v1alpha1.DashboardSummary, err error) {
 	var response *http.Response
-	url := fmt.Sprintf("%s/%s/summary", t.URL, cleanHTMLCharacters(dashboard))
+	var cancel context.CancelFunc
+	url := DashboardURL(t.URL, dashboard) + "/summary"
 
-	// request summary data from TestGrid
-	if response, err = http.Get(url); err != nil {
-		return nil, fmt.Errorf("error fetching testgrid dashboard summary endpoint: %v", err)
+	// request summary data from TestGrid, without following a permanent
+	// redirect of a renamed dashboard
+	client := *t.Client
+	client.CheckRedirect = stopPermanentRedirect(t.Client.CheckRedirect)
+	if response, cancel, err = t.get(ctx, &client, url); err != nil {
+		return nil, fmt.Errorf("%w: error fetching testgrid dashboard summary endpoint: %w", ErrTestGridUnavailable, err)
+	}
+	defer cancel()
+	defer response.Body.Close() // nolint:errcheck
+	if response.StatusCode == http.StatusNotFound || isPermanentRedirect(response.StatusCode) {
+		return nil, &DashboardError{Dashboard: dashboard, StatusCode: response.StatusCode, Location: response.Header.Get("Location")}
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: dashboard %s summary returned %s", ErrTestGridUnavailable, dashboard, response.Status)
 	}
 
 	var data []byte
@@ -120,8 +712,9 @@ func filterDashboards(dashboardList DashboardMapper, url string, filterStatus []
 			if dashboardSummary.DashboardTab == nil {
 				dashName := dashboardSummary.DashboardName
 				dashboardSummary.DashboardTab = &v1alpha1.DashboardTab{
-					TabURL:  cleanHTMLCharacters(fmt.Sprintf(tabURL, url, dashName, tabName, dashName)),
-					TabName: tabName,
+					TabURL:        cleanHTMLCharacters(fmt.Sprintf(tabURL, url, dashName, tabName, dashName)),
+					TabName:       tabName,
+					DashboardName: dashName,
 				}
 			}
 			summary = append(summary, *dashboardSummary)
@@ -130,11 +723,81 @@ func filterDashboards(dashboardList DashboardMapper, url string, filterStatus []
 	return summary
 }
 
+// FilterOptions selects the tests of a tab returned by FetchTabTests
+type FilterOptions struct {
+	// MinFailure is the minimum number of failures of a test in a failing tab, 0 disables it.
+	MinFailure int
+	// MinFailureRate is the minimum ratio of failures over the runs of a test in a
+	// failing tab, between 0 and 1, 0 disables it.
+	MinFailureRate float64
+	// MinFlake is the minimum number of flakes of a test in a flaky tab, 0 disables it.
+	MinFlake int
+	// MinFlakeRate is the minimum ratio of flakes over the runs of a test in a flaky
+	// tab, between 0 and 1, 0 disables it.
+	MinFlakeRate float64
+	// MinTotalRuns leaves out the tests with fewer runs in the window, whose
+	// rates are not meaningful, before the thresholds apply, 0 disables it.
+	MinTotalRuns int
+	// SIGMinFailure and SIGMinFlake override MinFailure and MinFlake for the
+	// tests labeled with a SIG, keyed by SIG name with or without the sig-
+	// prefix (e.g. node). A test of several SIGs uses the lowest threshold set
+	// for its SIGs, the global threshold only when none of its SIGs is set.
+	SIGMinFailure map[string]int
+	SIGMinFlake   map[string]int
+	// IncludePassing returns every test of the tab, including the passing ones.
+	IncludePassing bool
+	// LimitPerTab is the maximum number of tests returned for a tab, the most
+	// failing and flaky first, 0 disables it.
+	LimitPerTab int
+	// Sort orders the tabs and tests returned by Summarize, after filtering.
+	Sort Sort
+	// SinceRunID anchors the window of the tabs having this run, given by its
+	// build ID, counting only this run and the newer ones. Tabs without the run
+	// and an empty ID keep the whole TestGrid window.
+	SinceRunID string
+	// ExcludeLinkedBugs leaves out the tests with a bug linked in TestGrid, which
+	// are already triaged.
+	ExcludeLinkedBugs bool
+	// Acknowledged leaves out the acknowledged tests, known broken and tracked
+	// elsewhere, until their acknowledgment expires.
+	Acknowledged Acknowledgments
+	// RecoveredRuns leaves out the recovered flakes, the tests which flaked in
+	// the window but whose RecoveredRuns most recent runs with a result all
+	// passed, 0 disables it.
+	RecoveredRuns int
+	// Durations fetches the durations of the test runs, when TestGrid has them.
+	Durations bool
+	// MinDuration flags the tests whose latest run took at least this long as
+	// slow, 0 disables it. It requires Durations.
+	MinDuration time.Duration
+	// SummaryOnly returns the tabs of the dashboard summaries with their
+	// state, without fetching their tests, every other option but
+	// IncludePassing and Sort is ignored.
+	SummaryOnly bool
+}
+
 // FetchTabTests returns the test group related to the tab of a dashboard
-func (t *TestGrid) FetchTabTests(summary *v1alpha1.DashboardSummary, minFailure, minFlake int) (tab *v1alpha1.DashboardTab, err error) {
+func (t *TestGrid) FetchTabTests(summary *v1alpha1.DashboardSummary, opts FilterOptions) (*v1alpha1.DashboardTab, error) {
+	return t.fetchTabTests(t.context(), summary, opts)
+}
+
+func (t *TestGrid) fetchTabTests(ctx context.Context, summary *v1alpha1.DashboardSummary, opts FilterOptions) (tab *v1alpha1.DashboardTab, err error) {
 	var response *http.Response
-	if response, err = http.Get(summary.DashboardTab.TabURL); err != nil {
-		return tab, err
+	var cancel context.CancelFunc
+	tableURL := summary.DashboardTab.TabURL
+	if opts.IncludePassing {
+		tableURL = strings.Replace(tableURL, "&exclude-non-failed-tests=", "", 1)
+	}
+	if opts.Durations {
+		tableURL += "&graph-metrics=" + durationMetric
+	}
+	if response, cancel, err = t.get(ctx, t.Client, tableURL); err != nil {
+		return tab, fmt.Errorf("%w: %w", ErrTestGridUnavailable, err)
+	}
+	defer cancel()
+	defer response.Body.Close() // nolint:errcheck
+	if response.StatusCode != http.StatusOK {
+		return tab, fmt.Errorf("%w: tab %s returned %s", ErrTestGridUnavailable, summary.DashboardTab.TabName, response.Status)
 	}
 
 	var data []byte
@@ -147,53 +810,504 @@ func (t *TestGrid) FetchTabTests(summary *v1alpha1.DashboardSummary, minFailure,
 	if err = json.Unmarshal(data, testGroup); err != nil {
 		return tab, err
 	}
+	if opts.SinceRunID != "" {
+		testGroup.anchorAt(opts.SinceRunID)
+	}
 
+	summaryTab(summary)
+	tests := filterTabTests(testGroup, summary.OverallState, opts)
+	summary.DashboardTab.LinkedTests = 0
+	if opts.ExcludeLinkedBugs {
+		tests, summary.DashboardTab.LinkedTests = excludeLinkedBugs(tests)
+	}
+	summary.DashboardTab.AcknowledgedTests = 0
+	if len(opts.Acknowledged) > 0 {
+		tests, summary.DashboardTab.AcknowledgedTests = excludeAcknowledged(summary.DashboardTab, tests, opts.Acknowledged, time.Now())
+	}
+	summary.DashboardTab.RecoveredTests = 0
+	if opts.RecoveredRuns > 0 {
+		tests, summary.DashboardTab.RecoveredTests = excludeRecoveredFlakes(tests, opts.RecoveredRuns)
+	}
+	summary.DashboardTab.FailingTests = failingTests(tests)
+	summary.DashboardTab.TestRuns, summary.DashboardTab.HiddenTests = limitTests(tests, opts.LimitPerTab)
+
+	return summary.DashboardTab, nil
+}
+
+// summaryTab fills the tab of the summary with its state from the dashboard
+// summary alone, without its tests.
+func summaryTab(summary *v1alpha1.DashboardSummary) *v1alpha1.DashboardTab {
 	aggregation := fmt.Sprintf("%s#%s", summary.DashboardName, summary.DashboardTab.TabName)
 	icon := ":large_purple_square:"
-	if summary.OverallState == v1alpha1.FAILING_STATUS {
+	switch summary.OverallState {
+	case v1alpha1.FAILING_STATUS:
 		icon = ":large_red_square:"
+	case v1alpha1.PASSING_STATUS:
+		icon = ":large_green_square:"
 	}
 
 	summary.DashboardTab.BoardHash = aggregation
+	summary.DashboardTab.DashboardName = summary.DashboardName
 	summary.DashboardTab.TabURL = cleanHTMLCharacters(fmt.Sprintf("https://testgrid.k8s.io/%s&exclude-non-failed-tests=", aggregation))
-	summary.DashboardTab.TestRuns = filterTabTests(testGroup, summary.OverallState, minFailure, minFlake)
 	summary.DashboardTab.TabState = summary.OverallState
+	summary.DashboardTab.LastUpdated = unixMillis(summary.LastUpdateTime)
+	summary.DashboardTab.StatusMessage = strings.TrimSpace(summary.CurrentState)
 	summary.DashboardTab.StateIcon = icon
+	return summary.DashboardTab
+}
 
-	return summary.DashboardTab, nil
+// sigThreshold returns the threshold of the test name, the lowest threshold set
+// for its SIGs, the SIGs without threshold are ignored. A test none of whose
+// SIGs has a threshold, or without SIG, uses the global threshold.
+func sigThreshold(testName string, bySIG map[string]int, global int) int {
+	if len(bySIG) == 0 {
+		return global
+	}
+	threshold := -1
+	for _, sig := range ExtractSIGs(testName) {
+		value, ok := bySIG[sig]
+		if !ok {
+			value, ok = bySIG["sig-"+sig]
+		}
+		if ok && (threshold < 0 || value < threshold) {
+			threshold = value
+		}
+	}
+	if threshold < 0 {
+		return global
+	}
+	return threshold
 }
 
-func filterTabTests(testGroup *TestGroup, state string, minFailure, minFlake int) (tests []v1alpha1.TestResult) {
-	jobName := strings.Split(testGroup.Query, "/")
+func filterTabTests(testGroup *TestGroup, state string, opts FilterOptions) (tests []v1alpha1.TestResult) {
+	jobName := cleanHTMLCharacters(testGroup.Query[strings.LastIndex(testGroup.Query, "/")+1:])
+	tests = make([]v1alpha1.TestResult, 0, len(testGroup.Tests))
 	for _, test := range testGroup.Tests {
-		errMessage, failures, firstFailure := test.RenderStatuses(testGroup.Timestamps)
-		if ((failures >= minFailure || minFailure == 0) && state == v1alpha1.FAILING_STATUS) ||
-			((failures >= minFlake || minFlake == 0) && state == v1alpha1.FLAKY_STATUS) {
+		// count first, messages are only rendered for the selected tests
+		failures, firstFailure := test.failureStats()
+		runs := test.runCount()
+		// the failures of a test in a flaky tab are its flakes
+		failed, flakes := failures, 0
+		if state == v1alpha1.FLAKY_STATUS {
+			failed, flakes = 0, failures
+		}
+		if !opts.IncludePassing && runs < opts.MinTotalRuns {
+			continue
+		}
+		minFailure := sigThreshold(test.Name, opts.SIGMinFailure, opts.MinFailure)
+		minFlake := sigThreshold(test.Name, opts.SIGMinFlake, opts.MinFlake)
+		if opts.IncludePassing ||
+			((failures >= minFailure || minFailure == 0) && state == v1alpha1.FAILING_STATUS &&
+				aboveRate(failed, runs, opts.MinFailureRate)) ||
+			((failures >= minFlake || minFlake == 0) && state == v1alpha1.FLAKY_STATUS &&
+				aboveRate(flakes, runs, opts.MinFlakeRate)) {
+			errMessage, _, _ := test.RenderStatuses(testGroup.Timestamps)
 			testName := test.Name
 			if strings.Contains(testName, e2eSuitePrefix) {
-				testName = prow.GetRegexParameter(testRegex, testName)["TEST"]
+				testName = ""
+				if match := testNamePattern.FindStringSubmatch(test.Name); match != nil {
+					testName = match[testNamePattern.SubexpIndex("TEST")]
+				}
 			}
 			if strings.Contains(testName, kubetestPrefix) {
 				testName = strings.TrimPrefix(strings.TrimPrefix(testName, "kubetest2."), "kubetest.")
 			}
 
-			var prowJobURL string
-			if firstFailure >= 0 && firstFailure < len(testGroup.Changelists) {
-				prowJobURL = cleanHTMLCharacters(fmt.Sprintf("https://prow.k8s.io/view/gs/%s/%s", testGroup.Query, testGroup.Changelists[firstFailure]))
-			}
-			tests = append(tests, v1alpha1.TestResult{
+			result := v1alpha1.TestResult{
 				TestName:        test.Name,
 				LatestTimestamp: testGroup.Timestamps[0],
 				FirstTimestamp:  testGroup.Timestamps[len(testGroup.Timestamps)-1],
-				ProwJobURL:      prowJobURL,
-				TriageURL:       cleanHTMLCharacters(fmt.Sprintf("https://storage.googleapis.com/k8s-triage/index.html?job=%s$&test=%s", cleanHTMLCharacters(jobName[len(jobName)-1]), cleanHTMLCharacters(testName))),
+				ProwJobURL:      prowJobURL(testGroup, firstFailure),
+				TriageURL:       cleanHTMLCharacters(fmt.Sprintf("https://storage.googleapis.com/k8s-triage/index.html?job=%s$&test=%s", jobName, cleanHTMLCharacters(testName))),
 				ErrorMessage:    errMessage,
-			})
+				FailureCount:    failed,
+				FlakeCount:      flakes,
+				TotalRuns:       runs,
+				RunResults:      test.runResults(testGroup.Timestamps),
+				LinkedBugs:      test.LinkedBugs,
+				Alerting:        test.alerting(),
+			}
+			result.DurationSeconds, result.BaselineDurationSeconds = test.durationStats()
+			result.Slow = opts.MinDuration > 0 && result.DurationSeconds > 0 &&
+				time.Duration(result.DurationSeconds)*time.Second >= opts.MinDuration
+			tests = append(tests, result)
 		}
 	}
 	return tests
 }
 
+// prowJobURL returns the Spyglass page of the build of the column, the latest
+// failing run of a test, empty when the test group has no GCS path or no build
+// ID for the column.
+func prowJobURL(testGroup *TestGroup, column int) string {
+	if column < 0 || column >= len(testGroup.Changelists) {
+		return ""
+	}
+	query, build := strings.Trim(testGroup.Query, "/ "), strings.TrimSpace(testGroup.Changelists[column])
+	if query == "" || build == "" {
+		return ""
+	}
+	return cleanHTMLCharacters(fmt.Sprintf("https://prow.k8s.io/view/gs/%s/%s", query, build))
+}
+
+// ProgressEvent reports a step of Summarize, either a fetched dashboard summary
+// or a fetched tab with its partial result.
+type ProgressEvent struct {
+	// Dashboard is the dashboard being summarized.
+	Dashboard string
+	// Tab is the fetched tab, empty when the dashboard summary was fetched.
+	Tab string
+	// Tabs is the number of tabs to be fetched from the dashboard.
+	Tabs int
+	// Result is the fetched tab with the filtered tests, nil for dashboard events.
+	Result *v1alpha1.DashboardTab
+	// Err is the error of the step, if any.
+	Err error
+}
+
+// Summarize fetches the summary of each dashboard and the tests of its tabs, returning
+// the tabs with tests left after filtering. Steps are reported to the optional progress
+// channel without blocking, so it should be buffered, and it is closed on completion.
+// A failing dashboard or tab does not stop the scrape, the tabs fetched are returned
+// with the errors joined, naming the dashboards and tabs that were unavailable. The
+// tabs of a dashboard are fetched in parallel, up to Concurrency at a time, and
+// returned in the same order as a sequential fetch. Past the ScrapeTimeout, or once
+// the Context is canceled, the requests in flight are canceled and the rest is not
+// fetched. With SummaryOnly every tab of the summaries is returned without its tests,
+// by tab name.
+func (t *TestGrid) Summarize(dashboards []string, opts FilterOptions, progress chan<- ProgressEvent) ([]*v1alpha1.DashboardTab, error) {
+	if progress != nil {
+		defer close(progress)
+	}
+	parent := t.context()
+	ctx := parent
+	if t.ScrapeTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, t.ScrapeTimeout)
+		defer cancel()
+	}
+	// an error past the deadline is reported as such, whatever the request
+	// failed with, unless the parent context was canceled first
+	scrapeErr := func(err error) error {
+		if err != nil && ctx.Err() != nil && parent.Err() == nil {
+			return fmt.Errorf("%w after %s: %w", ErrScrapeTimeout, t.ScrapeTimeout, err)
+		}
+		return err
+	}
+	report := func(event ProgressEvent) {
+		if progress == nil {
+			return
+		}
+		select {
+		case progress <- event:
+		default:
+		}
+	}
+
+	statuses := v1alpha1.ERROR_STATUSES
+	if opts.IncludePassing {
+		statuses = append([]string{v1alpha1.PASSING_STATUS}, statuses...)
+	}
+
+	var (
+		dashboardTabs []*v1alpha1.DashboardTab
+		errs          []error
+	)
+	for _, dashboard := range dashboards {
+		if parent.Err() != nil {
+			errs = append(errs, &ScrapeError{Dashboard: dashboard, Err: parent.Err()})
+			continue
+		}
+		if ctx.Err() != nil {
+			errs = append(errs, &ScrapeError{Dashboard: dashboard, Err: fmt.Errorf("%w after %s", ErrScrapeTimeout, t.ScrapeTimeout)})
+			continue
+		}
+		dashSummaries, err := t.fetchTabSummary(ctx, dashboard, statuses)
+		err = scrapeErr(err)
+		report(ProgressEvent{Dashboard: dashboard, Tabs: len(dashSummaries), Err: err})
+		var dashboardErr *DashboardError
+		if errors.As(err, &dashboardErr) {
+			slog.Warn(dashboardErr.Error())
+			errs = append(errs, dashboardErr)
+			continue
+		}
+		if err != nil {
+			errs = append(errs, &ScrapeError{Dashboard: dashboard, Err: err})
+			continue
+		}
+		if opts.SummaryOnly {
+			// the summary lists the tabs in no particular order
+			summaryTabs := make([]*v1alpha1.DashboardTab, len(dashSummaries))
+			for i := range dashSummaries {
+				summaryTabs[i] = summaryTab(&dashSummaries[i])
+			}
+			sort.SliceStable(summaryTabs, func(i, j int) bool { return summaryTabs[i].TabName < summaryTabs[j].TabName })
+			dashboardTabs = append(dashboardTabs, summaryTabs...)
+			continue
+		}
+		tabs := make([]*v1alpha1.DashboardTab, len(dashSummaries))
+		tabErrs := make([]error, len(dashSummaries))
+		var wg sync.WaitGroup
+		workers := make(chan struct{}, max(t.Concurrency, 1))
+		for i := range dashSummaries {
+			wg.Add(1)
+			workers <- struct{}{}
+			go func() {
+				defer wg.Done()
+				defer func() { <-workers }()
+				dashSummary := &dashSummaries[i]
+				tabs[i], tabErrs[i] = t.fetchTabTests(ctx, dashSummary, opts)
+				tabErrs[i] = scrapeErr(tabErrs[i])
+				report(ProgressEvent{Dashboard: dashboard, Tab: dashSummary.DashboardTab.TabName, Result: tabs[i], Err: tabErrs[i]})
+			}()
+		}
+		wg.Wait()
+		for i, dashTab := range tabs {
+			if tabErrs[i] != nil {
+				errs = append(errs, &ScrapeError{Dashboard: dashboard, Tab: dashSummaries[i].DashboardTab.TabName, Err: tabErrs[i]})
+				continue
+			}
+			if len(dashTab.TestRuns) > 0 || excludedTests(dashTab) > 0 {
+				dashboardTabs = append(dashboardTabs, dashTab)
+			}
+		}
+	}
+	opts.Sort.Apply(dashboardTabs)
+	return dashboardTabs, errors.Join(errs...)
+}
+
+// unixMillis returns the TestGrid timestamp in milliseconds, the summary has
+// timestamps in seconds or in milliseconds.
+func unixMillis(timestamp int64) int64 {
+	if timestamp > 0 && timestamp < 1e11 {
+		return timestamp * 1000
+	}
+	return timestamp
+}
+
+// Staleness returns the time elapsed at now since TestGrid last updated the
+// tab, 0 when the update time is unknown.
+func Staleness(tab *v1alpha1.DashboardTab, now time.Time) time.Duration {
+	if tab.LastUpdated <= 0 {
+		return 0
+	}
+	return max(now.Sub(time.UnixMilli(tab.LastUpdated)), 0)
+}
+
+// StaleTabs returns the tabs TestGrid did not update for longer than the max
+// staleness at now, the least recently updated first.
+func StaleTabs(tabs []*v1alpha1.DashboardTab, maxStaleness time.Duration, now time.Time) []*v1alpha1.DashboardTab {
+	var stale []*v1alpha1.DashboardTab
+	for _, tab := range tabs {
+		if Staleness(tab, now) > maxStaleness {
+			stale = append(stale, tab)
+		}
+	}
+	sort.SliceStable(stale, func(i, j int) bool { return stale[i].LastUpdated < stale[j].LastUpdated })
+	return stale
+}
+
+// healthGlyphs are the glyphs of the tab health states
+var healthGlyphs = map[string]string{
+	v1alpha1.FAILING_STATUS: "✗",
+	v1alpha1.FLAKY_STATUS:   "⚠",
+	v1alpha1.PASSING_STATUS: "✓",
+}
+
+// TabHealth classifies the tab by its listed tests, regardless of the state
+// TestGrid reports for it: failing when any test has a failure, flaky when the
+// tests only have flakes, else passing.
+func TabHealth(tab *v1alpha1.DashboardTab) string {
+	health := v1alpha1.PASSING_STATUS
+	for _, test := range tab.TestRuns {
+		if test.FailureCount > 0 {
+			return v1alpha1.FAILING_STATUS
+		}
+		if test.FlakeCount > 0 {
+			health = v1alpha1.FLAKY_STATUS
+		}
+	}
+	return health
+}
+
+// HealthGlyph returns the glyph of the tab health, ✗ failing, ⚠ flaky or ✓
+// passing.
+func HealthGlyph(tab *v1alpha1.DashboardTab) string {
+	return healthGlyphs[TabHealth(tab)]
+}
+
+// sparkBlocks are the blocks of the run results in a sparkline, the taller
+// the worse, and a middle dot for the runs without result.
+var sparkBlocks = map[string]rune{
+	v1alpha1.RUN_PASSED:    '▁',
+	v1alpha1.RUN_FLAKY:     '▄',
+	v1alpha1.RUN_FAILED:    '█',
+	v1alpha1.RUN_NO_RESULT: '·',
+}
+
+// Sparkline renders the last runs of the results, at most n, as a unicode
+// sparkline read from the oldest run on the left to the newest on the right,
+// e.g. ▁▁▄▁██ for a test failing since two runs. It is empty without results.
+func Sparkline(results []v1alpha1.RunResult, n int) string {
+	results = results[:min(n, len(results))]
+	spark := make([]rune, len(results))
+	for i, result := range results {
+		block, ok := sparkBlocks[result.Result]
+		if !ok {
+			block = sparkBlocks[v1alpha1.RUN_NO_RESULT]
+		}
+		// the results are newest first
+		spark[len(results)-1-i] = block
+	}
+	return string(spark)
+}
+
+// historyGlyphs are the ASCII glyphs of the run results in a history, for the
+// reports read in logs and terminals without unicode.
+var historyGlyphs = map[string]byte{
+	v1alpha1.RUN_PASSED:    '.',
+	v1alpha1.RUN_FLAKY:     'f',
+	v1alpha1.RUN_FAILED:    'x',
+	v1alpha1.RUN_NO_RESULT: '-',
+}
+
+// History renders the last runs of the results, at most n, as ASCII glyphs read
+// like a Sparkline from the oldest run on the left, e.g. ...xx for a test failing
+// since two runs. It is empty without results.
+func History(results []v1alpha1.RunResult, n int) string {
+	results = results[:min(max(n, 0), len(results))]
+	history := make([]byte, len(results))
+	for i, result := range results {
+		glyph, ok := historyGlyphs[result.Result]
+		if !ok {
+			glyph = historyGlyphs[v1alpha1.RUN_NO_RESULT]
+		}
+		history[len(results)-1-i] = glyph
+	}
+	return string(history)
+}
+
+// FailingTests returns the number of distinct tests of the tab with at least
+// one failure, including the tests left out by the limit per tab.
+func FailingTests(tab *v1alpha1.DashboardTab) int {
+	return max(tab.FailingTests, failingTests(tab.TestRuns))
+}
+
+// failingTests returns the number of tests with at least one failure.
+func failingTests(tests []v1alpha1.TestResult) (failing int) {
+	for _, test := range tests {
+		if test.FailureCount > 0 {
+			failing++
+		}
+	}
+	return failing
+}
+
+// Widespread reports whether at least min distinct tests of the tab fail, min
+// included, a probable infra or systemic breakage rather than single test
+// regressions. A min of 0 disables it.
+func Widespread(tab *v1alpha1.DashboardTab, min int) bool {
+	return min > 0 && FailingTests(tab) >= min
+}
+
+// FormatAge renders an age in the largest unit, e.g. 3d, 5h or 12m.
+func FormatAge(age time.Duration) string {
+	switch {
+	case age >= 48*time.Hour:
+		return fmt.Sprintf("%dd", int(age.Hours()/24))
+	case age >= time.Hour:
+		return fmt.Sprintf("%dh", int(age.Hours()))
+	}
+	return fmt.Sprintf("%dm", int(age.Minutes()))
+}
+
+// ExtractSIGs returns the SIGs labeled in the test name, e.g. "node" for [sig-node].
+func ExtractSIGs(testName string) (sigs []string) {
+	for _, match := range sigPattern.FindAllStringSubmatch(testName, -1) {
+		sigs = append(sigs, match[1])
+	}
+	return sigs
+}
+
+// limitTests keeps the limit tests with the most failures and flakes, in a
+// stable order, and returns the number of tests left out.
+func limitTests(tests []v1alpha1.TestResult, limit int) ([]v1alpha1.TestResult, int) {
+	if limit <= 0 || len(tests) <= limit {
+		return tests, 0
+	}
+	sort.SliceStable(tests, func(i, j int) bool {
+		return tests[i].FailureCount+tests[i].FlakeCount > tests[j].FailureCount+tests[j].FlakeCount
+	})
+	return tests[:limit:limit], len(tests) - limit
+}
+
+// excludedTests returns the number of tests of the tab left out for having a
+// linked bug, being acknowledged or a recovered flake, a tab without tests left
+// is still returned by Summarize when it has excluded tests, so they are
+// counted.
+func excludedTests(tab *v1alpha1.DashboardTab) int {
+	return tab.LinkedTests + tab.AcknowledgedTests + tab.RecoveredTests
+}
+
+// excludeLinkedBugs removes the tests with a linked bug and returns the number
+// of tests removed.
+func excludeLinkedBugs(tests []v1alpha1.TestResult) ([]v1alpha1.TestResult, int) {
+	kept := tests[:0]
+	for _, test := range tests {
+		if len(test.LinkedBugs) == 0 {
+			kept = append(kept, test)
+		}
+	}
+	return kept, len(tests) - len(kept)
+}
+
+// excludeRecoveredFlakes removes the recovered flakes, see recoveredFlake, and
+// returns the number of tests removed.
+func excludeRecoveredFlakes(tests []v1alpha1.TestResult, runs int) ([]v1alpha1.TestResult, int) {
+	kept := tests[:0]
+	for _, test := range tests {
+		if !recoveredFlake(test, runs) {
+			kept = append(kept, test)
+		}
+	}
+	return kept, len(tests) - len(kept)
+}
+
+// recoveredFlake returns true when the test flaked in the window, with flakes
+// in a flaky tab or a flaky run, and its runs most recent runs with a result
+// all passed. The runs without a result are skipped, and a test with fewer
+// runs with a result is not recovered.
+func recoveredFlake(test v1alpha1.TestResult, runs int) bool {
+	if test.FlakeCount == 0 && !slices.ContainsFunc(test.RunResults, func(run v1alpha1.RunResult) bool {
+		return run.Result == v1alpha1.RUN_FLAKY
+	}) {
+		return false
+	}
+	passed := 0
+	for _, run := range test.RunResults {
+		switch run.Result {
+		case v1alpha1.RUN_NO_RESULT:
+			continue
+		case v1alpha1.RUN_PASSED:
+			if passed++; passed == runs {
+				return true
+			}
+			continue
+		}
+		return false
+	}
+	return false
+}
+
+// aboveRate returns true when the ratio of count over runs reaches the minimum
+// rate, a zero minimum disables the check.
+func aboveRate(count, runs int, minRate float64) bool {
+	if minRate == 0 {
+		return true
+	}
+	return runs > 0 && float64(count)/float64(runs) >= minRate
+}
+
 func hasStatus(boardStatus string, statuses []string) bool {
 	for _, status := range statuses {
 		if boardStatus == status {
@@ -205,8 +1319,24 @@ func hasStatus(boardStatus string, statuses []string) bool {
 
 // formatTestStatus creates a formatted string for a single test status.
 func formatTestStatus(shortText string, timestamp int64, message string) string {
-	timeFormatted := time.Unix(timestamp/1000, 0)
-	return fmt.Sprintf("\t%s %s %s\n", shortText, timeFormatted, message)
+	return string(appendTestStatus(nil, shortText, timestamp, message))
+}
+
+// appendTestStatus appends a formatted test status to the buffer, the
+// time layout is the same as time.Time.String.
+func appendTestStatus(buf []byte, shortText string, timestamp int64, message string) []byte {
+	buf = append(buf, '\t')
+	buf = append(buf, shortText...)
+	buf = append(buf, ' ')
+	buf = time.Unix(timestamp/1000, 0).AppendFormat(buf, "2006-01-02 15:04:05.999999999 -0700 MST")
+	buf = append(buf, ' ')
+	buf = append(buf, message...)
+	return append(buf, '\n')
+}
+
+// DashboardURL returns the page of the dashboard on the TestGrid at the base URL.
+func DashboardURL(base, dashboard string) string {
+	return fmt.Sprintf("%s/%s", base, cleanHTMLCharacters(dashboard))
 }
 
 func cleanHTMLCharacters(str string) string {
@@ -1,10 +1,20 @@
 package testgrid
 
 import (
+	"context"
 	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"sigs.k8s.io/signalhound/api/v1alpha1"
@@ -65,6 +75,7 @@ func Test_FetchSummary(t *testing.T) {
 				for _, dash := range summary {
 					assert.Equal(t, dash.DashboardName, dashboard)
 					assert.Equal(t, dash.DashboardTab.TabName, tabName)
+					assert.Equal(t, dash.DashboardTab.DashboardName, dashboard)
 					assert.Contains(t, dash.DashboardTab.TabURL, tabName)
 				}
 			}
@@ -72,6 +83,84 @@ func Test_FetchSummary(t *testing.T) {
 	}
 }
 
+func Test_FetchSummaryUnavailable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	_, err := NewTestGrid(server.URL).FetchTabSummary(dashboard, v1alpha1.ERROR_STATUSES)
+	assert.ErrorIs(t, err, ErrTestGridUnavailable)
+}
+
+func Test_SummarizeDashboardNotFound(t *testing.T) {
+	const removed, renamed = "sig-release-removed", "sig-release-renamed"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/"+removed):
+			http.NotFound(w, r)
+			return
+		case strings.HasPrefix(r.URL.Path, "/"+renamed):
+			http.Redirect(w, r, "/"+dashboard+"/summary", http.StatusPermanentRedirect)
+			return
+		}
+		var response interface{} = TestGroup{
+			Query:       "kubernetes-ci-logs/logs/ci-kubernetes-build",
+			Timestamps:  []int64{1758999193000},
+			Changelists: []string{"1972011571991285760"},
+			Tests:       []Test{{Name: "ci-kubernetes-build.Overall", ShortTexts: []string{"F"}, Messages: []string{"F"}}},
+		}
+		if strings.HasSuffix(r.URL.Path, "/summary") {
+			response = DashboardMapper{tabName: {OverallState: v1alpha1.FAILING_STATUS, DashboardName: dashboard}}
+		}
+		jsonData, _ := json.Marshal(response)
+		w.Write(jsonData) // nolint
+	}))
+	defer server.Close()
+
+	tabs, err := NewTestGrid(server.URL).Summarize([]string{removed, renamed, dashboard}, FilterOptions{}, nil)
+	assert.ErrorIs(t, err, ErrDashboardNotFound)
+	assert.NotErrorIs(t, err, ErrTestGridUnavailable)
+	assert.Len(t, tabs, 1)
+	assert.Equal(t, []*DashboardError{
+		{Dashboard: removed, StatusCode: http.StatusNotFound},
+		{Dashboard: renamed, StatusCode: http.StatusPermanentRedirect, Location: "/" + dashboard + "/summary"},
+	}, DashboardErrors(err))
+	assert.Contains(t, err.Error(), "dashboard sig-release-removed not found (renamed?)")
+	assert.Empty(t, FailedDashboards(err))
+}
+
+func TestFailedDashboards(t *testing.T) {
+	err := errors.Join(
+		&ScrapeError{Dashboard: "sig-release-master-blocking", Tab: "build-master", Err: ErrTestGridUnavailable},
+		&DashboardError{Dashboard: "sig-release-removed", StatusCode: http.StatusNotFound},
+		&ScrapeError{Dashboard: "sig-release-master-informing", Err: ErrTestGridUnavailable},
+		&ScrapeError{Dashboard: "sig-release-master-blocking", Tab: "verify-master", Err: ErrTestGridUnavailable},
+	)
+	assert.Equal(t, []string{"sig-release-master-blocking", "sig-release-master-informing"}, FailedDashboards(err))
+	assert.ErrorIs(t, err, ErrTestGridUnavailable)
+	assert.Contains(t, err.Error(), "dashboard sig-release-master-blocking tab build-master: testgrid unavailable")
+	assert.Empty(t, FailedDashboards(nil))
+}
+
+func Test_ListDashboards(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/dashboards", r.URL.Path)
+		w.Write([]byte(`{"dashboards":[{"name":"sig-release-master-informing"},{"name":"sig-release-master-blocking"}]}`)) // nolint
+	}))
+	defer server.Close()
+
+	names, err := NewTestGrid(server.URL).ListDashboards()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sig-release-master-blocking", "sig-release-master-informing"}, names)
+
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	_, err = NewTestGrid(server.URL).ListDashboards()
+	assert.ErrorIs(t, err, ErrTestGridUnavailable)
+}
+
 func Test_FetchTable(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -111,11 +200,12 @@ func Test_FetchTable(t *testing.T) {
 			}
 
 			tg := NewTestGrid(server.URL)
-			tabTest, err := tg.FetchTabTests(summary, 1, 1)
+			tabTest, err := tg.FetchTabTests(summary, FilterOptions{MinFailure: 1, MinFlake: 1})
 			assert.NoError(t, err)
 
 			assert.NotEmpty(t, tabTest.StateIcon)
 			assert.Equal(t, v1alpha1.FLAKY_STATUS, tabTest.TabState)
+			assert.Equal(t, dashboard, tabTest.DashboardName)
 			assert.Len(t, tabTest.TestRuns, 1)
 			for _, test := range tabTest.TestRuns {
 				assert.Contains(t, test.TestName, "Overall")
@@ -125,6 +215,740 @@ func Test_FetchTable(t *testing.T) {
 	}
 }
 
+func Test_FilterTabTests(t *testing.T) {
+	testGroup := &TestGroup{
+		Query:       "kubernetes-ci-logs/logs/ci-kubernetes-build",
+		Timestamps:  []int64{1758999193000, 1758992000000},
+		Changelists: []string{"1972011571991285760", "1972011571991285759"},
+		Tests: []Test{
+			{Name: "failing", ShortTexts: []string{"F", "F"}, Messages: []string{"F", "F"}},
+			{Name: "passing", ShortTexts: []string{"", ""}, Messages: []string{"", ""}},
+		},
+	}
+	tests := []struct {
+		name     string
+		state    string
+		opts     FilterOptions
+		expected []string
+	}{
+		{name: "failing tab above threshold", state: v1alpha1.FAILING_STATUS, opts: FilterOptions{MinFailure: 1}, expected: []string{"failing"}},
+		{name: "failing tab below threshold", state: v1alpha1.FAILING_STATUS, opts: FilterOptions{MinFailure: 3}},
+		{name: "passing tab is filtered", state: v1alpha1.PASSING_STATUS},
+		{name: "passing tests included", state: v1alpha1.PASSING_STATUS, opts: FilterOptions{IncludePassing: true}, expected: []string{"failing", "passing"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var names []string
+			for _, test := range filterTabTests(testGroup, tt.state, tt.opts) {
+				names = append(names, test.TestName)
+			}
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+}
+
+func TestRunResults(t *testing.T) {
+	timestamps := []int64{4000, 3000, 2000, 1000}
+	tests := []struct {
+		name     string
+		test     Test
+		expected []v1alpha1.RunResult
+	}{
+		{
+			name: "run-length encoded statuses",
+			test: Test{Statuses: []Statuses{{Count: 1, Value: 12}, {Count: 2, Value: 1}, {Count: 1, Value: 13}}},
+			expected: []v1alpha1.RunResult{
+				{Timestamp: 4000, Result: v1alpha1.RUN_FAILED},
+				{Timestamp: 3000, Result: v1alpha1.RUN_PASSED},
+				{Timestamp: 2000, Result: v1alpha1.RUN_PASSED},
+				{Timestamp: 1000, Result: v1alpha1.RUN_FLAKY},
+			},
+		},
+		{
+			name: "runs without result",
+			test: Test{Statuses: []Statuses{{Count: 2, Value: statusNoResult}, {Count: 2, Value: 1}}},
+			expected: []v1alpha1.RunResult{
+				{Timestamp: 4000, Result: v1alpha1.RUN_NO_RESULT},
+				{Timestamp: 3000, Result: v1alpha1.RUN_NO_RESULT},
+				{Timestamp: 2000, Result: v1alpha1.RUN_PASSED},
+				{Timestamp: 1000, Result: v1alpha1.RUN_PASSED},
+			},
+		},
+		{
+			name: "short texts without statuses",
+			test: Test{ShortTexts: []string{"", "F", "", ""}},
+			expected: []v1alpha1.RunResult{
+				{Timestamp: 4000, Result: v1alpha1.RUN_PASSED},
+				{Timestamp: 3000, Result: v1alpha1.RUN_FAILED},
+				{Timestamp: 2000, Result: v1alpha1.RUN_PASSED},
+				{Timestamp: 1000, Result: v1alpha1.RUN_PASSED},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.test.runResults(timestamps))
+		})
+	}
+
+	long := make([]int64, MaxRunResults*2)
+	test := Test{Statuses: []Statuses{{Count: len(long), Value: 1}}}
+	assert.Len(t, test.runResults(long), MaxRunResults)
+}
+
+func Test_FilterTabTestsFlakeRate(t *testing.T) {
+	testGroup := &TestGroup{
+		Query:       "kubernetes-ci-logs/logs/ci-kubernetes-e2e",
+		Timestamps:  []int64{1758999193000, 1758992000000, 1758985000000, 1758978000000},
+		Changelists: []string{"4", "3", "2", "1"},
+		Tests: []Test{
+			{
+				Name: "often", ShortTexts: []string{"F", "", "F", ""}, Messages: []string{"F", "", "F", ""},
+				Statuses: []Statuses{{Count: 1, Value: 12}, {Count: 1, Value: 1}, {Count: 1, Value: 12}, {Count: 1, Value: 1}},
+			},
+			{
+				Name: "rarely", ShortTexts: []string{"", "", "F", ""}, Messages: []string{"", "", "F", ""},
+				Statuses: []Statuses{{Count: 2, Value: 1}, {Count: 1, Value: 12}, {Count: 1, Value: 1}},
+			},
+			{
+				Name: "new", ShortTexts: []string{"F", "", "", ""}, Messages: []string{"F", "", "", ""},
+				Statuses: []Statuses{{Count: 1, Value: 12}, {Count: 3, Value: statusNoResult}},
+			},
+		},
+	}
+	tests := []struct {
+		name     string
+		opts     FilterOptions
+		expected []string
+	}{
+		{name: "rate disabled", expected: []string{"often", "rarely", "new"}},
+		{name: "above a third of the runs", opts: FilterOptions{MinFlakeRate: 0.3}, expected: []string{"often", "new"}},
+		{name: "combined with the count", opts: FilterOptions{MinFlake: 2, MinFlakeRate: 0.3}, expected: []string{"often"}},
+		{name: "too few runs", opts: FilterOptions{MinTotalRuns: 2}, expected: []string{"often", "rarely"}},
+		{name: "too few runs for the rate", opts: FilterOptions{MinFlakeRate: 0.3, MinTotalRuns: 4}, expected: []string{"often"}},
+		{name: "single run at a full rate", opts: FilterOptions{MinFlakeRate: 0.5, MinTotalRuns: 2}, expected: []string{"often"}},
+		{name: "passing kept regardless of the runs", opts: FilterOptions{IncludePassing: true, MinTotalRuns: 10}, expected: []string{"often", "rarely", "new"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var names []string
+			for _, test := range filterTabTests(testGroup, v1alpha1.FLAKY_STATUS, tt.opts) {
+				names = append(names, test.TestName)
+			}
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+
+	results := filterTabTests(testGroup, v1alpha1.FLAKY_STATUS, FilterOptions{})
+	assert.Equal(t, 2, results[0].FlakeCount)
+	assert.Equal(t, 4, results[0].TotalRuns)
+	assert.Equal(t, 1, results[2].TotalRuns)
+	assert.Equal(t, 0, results[0].FailureCount)
+
+	failing := filterTabTests(testGroup, v1alpha1.FAILING_STATUS, FilterOptions{})
+	assert.Equal(t, 0, failing[0].FlakeCount)
+	assert.Equal(t, 2, failing[0].FailureCount)
+
+	var names []string
+	for _, test := range filterTabTests(testGroup, v1alpha1.FAILING_STATUS, FilterOptions{MinFailureRate: 0.5}) {
+		names = append(names, test.TestName)
+	}
+	assert.Equal(t, []string{"often", "new"}, names)
+}
+
+func TestProwJobURL(t *testing.T) {
+	testGroup := &TestGroup{Query: "kubernetes-ci-logs/logs/ci-kubernetes-e2e", Changelists: []string{"1971", "", "1969"}}
+	assert.Equal(t, "https://prow.k8s.io/view/gs/kubernetes-ci-logs/logs/ci-kubernetes-e2e/1971", prowJobURL(testGroup, 0))
+	assert.Equal(t, "https://prow.k8s.io/view/gs/kubernetes-ci-logs/logs/ci-kubernetes-e2e/1969", prowJobURL(testGroup, 2))
+	// no failing run, no build ID or no column
+	assert.Empty(t, prowJobURL(testGroup, -1))
+	assert.Empty(t, prowJobURL(testGroup, 1))
+	assert.Empty(t, prowJobURL(testGroup, 3))
+	// test groups not stored on GCS have no job page
+	assert.Empty(t, prowJobURL(&TestGroup{Changelists: []string{"1971"}}, 0))
+}
+
+func Test_FilterTabTestsLinkedBugs(t *testing.T) {
+	var testGroup TestGroup
+	assert.NoError(t, json.Unmarshal([]byte(`{
+		"query": "kubernetes-ci-logs/logs/ci-kubernetes-e2e",
+		"timestamps": [1758999193000, 1758992000000],
+		"changelists": ["2", "1"],
+		"tests": [
+			{"name": "triaged", "messages": ["F", "F"], "short_texts": ["F", "F"], "statuses": [{"count": 2, "value": 12}],
+				"alert": {"fail_count": 2}, "linked_bugs": ["https://github.com/kubernetes/kubernetes/issues/1"]},
+			{"name": "untriaged", "messages": ["F", ""], "short_texts": ["F", ""], "statuses": [{"count": 1, "value": 12}, {"count": 1, "value": 1}],
+				"alert": null, "linked_bugs": []}
+		]
+	}`), &testGroup))
+
+	results := filterTabTests(&testGroup, v1alpha1.FAILING_STATUS, FilterOptions{})
+	assert.Len(t, results, 2)
+	assert.Equal(t, []string{"https://github.com/kubernetes/kubernetes/issues/1"}, results[0].LinkedBugs)
+	assert.True(t, results[0].Alerting)
+	assert.Empty(t, results[1].LinkedBugs)
+	assert.False(t, results[1].Alerting)
+}
+
+func TestAnchorAt(t *testing.T) {
+	newTestGroup := func() *TestGroup {
+		return &TestGroup{
+			Timestamps:  []int64{4000, 3000, 2000, 1000},
+			Changelists: []string{"4", "3", "2", "1"},
+			ColumnIds:   []string{"build-4", "build-3", "build-2", "build-1"},
+			Tests: []Test{
+				{
+					Name: "often", ShortTexts: []string{"F", "", "F", ""}, Messages: []string{"F", "", "F", ""},
+					Statuses: []Statuses{{Count: 1, Value: 12}, {Count: 1, Value: 1}, {Count: 1, Value: 12}, {Count: 1, Value: 1}},
+				},
+				{
+					Name: "old", ShortTexts: []string{"", "", "F", "F"}, Messages: []string{"", "", "F", "F"},
+					Statuses: []Statuses{{Count: 2, Value: 1}, {Count: 2, Value: 12}},
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name     string
+		runID    string
+		anchored bool
+		columns  int
+		failures map[string]int
+	}{
+		{name: "changelist", runID: "3", anchored: true, columns: 2, failures: map[string]int{"often": 1}},
+		{name: "column id", runID: "build-2", anchored: true, columns: 3, failures: map[string]int{"often": 2, "old": 1}},
+		{name: "newest run", runID: "4", anchored: true, columns: 1, failures: map[string]int{"often": 1}},
+		{name: "unknown run keeps the window", runID: "5", columns: 4, failures: map[string]int{"often": 2, "old": 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testGroup := newTestGroup()
+			assert.Equal(t, tt.anchored, testGroup.anchorAt(tt.runID))
+			assert.Len(t, testGroup.Timestamps, tt.columns)
+
+			failures := map[string]int{}
+			for _, test := range filterTabTests(testGroup, v1alpha1.FAILING_STATUS, FilterOptions{MinFailure: 1}) {
+				assert.Equal(t, tt.columns, test.TotalRuns)
+				assert.Len(t, test.RunResults, tt.columns)
+				assert.Equal(t, testGroup.Timestamps[tt.columns-1], test.FirstTimestamp)
+				failures[test.TestName] = test.FailureCount
+			}
+			assert.Equal(t, tt.failures, failures)
+		})
+	}
+}
+
+func TestDurationStats(t *testing.T) {
+	graphs := func(values ...float64) []Graph {
+		return []Graph{{Metric: []string{durationMetric}, Values: [][]float64{values}}}
+	}
+	tests := []struct {
+		name     string
+		test     Test
+		latest   int64
+		baseline int64
+	}{
+		{name: "no timing data", test: Test{}},
+		{name: "other metric", test: Test{Graphs: []Graph{{Metric: []string{"memory"}, Values: [][]float64{{1, 2}}}}}},
+		{name: "too few older runs", test: Test{Graphs: graphs(10, 5, 5)}, latest: 600},
+		{name: "median of the older runs", test: Test{Graphs: graphs(20, 5, 6, 4)}, latest: 1200, baseline: 300},
+		{name: "even number of older runs", test: Test{Graphs: graphs(10, 4, 5, 6, 7)}, latest: 600, baseline: 330},
+		{name: "runs without value are skipped", test: Test{Graphs: graphs(0, 8, 0, 4, 4, 4)}, latest: 480, baseline: 240},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			latest, baseline := tt.test.durationStats()
+			assert.Equal(t, tt.latest, latest)
+			assert.Equal(t, tt.baseline, baseline)
+		})
+	}
+}
+
+func Test_FilterTabTestsMinDuration(t *testing.T) {
+	testGroup := &TestGroup{
+		Query:      "kubernetes-ci-logs/logs/ci-kubernetes-e2e",
+		Timestamps: []int64{1758999193000, 1758992000000},
+		Tests: []Test{
+			{Name: "slow", ShortTexts: []string{"F", ""}, Messages: []string{"F", ""}, Graphs: []Graph{{Metric: []string{durationMetric}, Values: [][]float64{{90, 30}}}}},
+			{Name: "fast", ShortTexts: []string{"F", ""}, Messages: []string{"F", ""}, Graphs: []Graph{{Metric: []string{durationMetric}, Values: [][]float64{{10, 30}}}}},
+		},
+	}
+	results := filterTabTests(testGroup, v1alpha1.FAILING_STATUS, FilterOptions{MinDuration: time.Hour})
+	assert.Len(t, results, 2)
+	assert.True(t, results[0].Slow)
+	assert.Equal(t, int64(5400), results[0].DurationSeconds)
+	assert.False(t, results[1].Slow)
+}
+
+func Test_FilterTabTestsSIGThresholds(t *testing.T) {
+	testGroup := &TestGroup{
+		Query:      "kubernetes-ci-logs/logs/ci-kubernetes-e2e",
+		Timestamps: []int64{1758999193000, 1758992000000, 1758990000000},
+		Tests: []Test{
+			{Name: "Kubernetes e2e suite.[It] [sig-node] Pods should restart", ShortTexts: []string{"F", "F", ""}, Messages: []string{"F", "F", ""}},
+			{Name: "Kubernetes e2e suite.[It] [sig-storage] Volumes should mount", ShortTexts: []string{"F", "F", ""}, Messages: []string{"F", "F", ""}},
+			{Name: "Kubernetes e2e suite.[It] [sig-node] [sig-storage] Pods should mount", ShortTexts: []string{"F", "F", ""}, Messages: []string{"F", "F", ""}},
+			{Name: "ci-kubernetes-e2e.Overall", ShortTexts: []string{"F", "", ""}, Messages: []string{"F", "", ""}},
+		},
+	}
+	names := func(results []v1alpha1.TestResult) (names []string) {
+		for _, result := range results {
+			names = append(names, result.TestName)
+		}
+		return names
+	}
+	tests := []struct {
+		name  string
+		state string
+		opts  FilterOptions
+		want  []string
+	}{
+		{
+			name:  "sig over the global threshold of another sig",
+			state: v1alpha1.FAILING_STATUS,
+			opts:  FilterOptions{MinFailure: 1, SIGMinFailure: map[string]int{"node": 3}},
+			want:  []string{testGroup.Tests[1].Name, testGroup.Tests[3].Name},
+		},
+		{
+			name:  "sig prefix and no global threshold",
+			state: v1alpha1.FAILING_STATUS,
+			opts:  FilterOptions{SIGMinFailure: map[string]int{"sig-node": 3, "storage": 3}},
+			want:  []string{testGroup.Tests[3].Name},
+		},
+		{
+			name:  "lowest threshold of several sigs",
+			state: v1alpha1.FAILING_STATUS,
+			opts:  FilterOptions{MinFailure: 1, SIGMinFailure: map[string]int{"node": 3, "storage": 2}},
+			want:  []string{testGroup.Tests[1].Name, testGroup.Tests[2].Name, testGroup.Tests[3].Name},
+		},
+		{
+			name:  "flake threshold by sig",
+			state: v1alpha1.FLAKY_STATUS,
+			opts:  FilterOptions{MinFlake: 2, SIGMinFailure: map[string]int{"storage": 5}, SIGMinFlake: map[string]int{"node": 5}},
+			want:  []string{testGroup.Tests[1].Name},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, names(filterTabTests(testGroup, tt.state, tt.opts)))
+		})
+	}
+}
+
+func TestStaleTabs(t *testing.T) {
+	now := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+	fresh := &v1alpha1.DashboardTab{TabName: "fresh", LastUpdated: now.Add(-time.Hour).UnixMilli()}
+	stale := &v1alpha1.DashboardTab{TabName: "stale", LastUpdated: now.Add(-7 * time.Hour).UnixMilli()}
+	staler := &v1alpha1.DashboardTab{TabName: "staler", LastUpdated: now.Add(-72 * time.Hour).UnixMilli()}
+	unknown := &v1alpha1.DashboardTab{TabName: "unknown"}
+
+	assert.Equal(t, []*v1alpha1.DashboardTab{staler, stale}, StaleTabs([]*v1alpha1.DashboardTab{fresh, stale, unknown, staler}, 6*time.Hour, now))
+	assert.Empty(t, StaleTabs([]*v1alpha1.DashboardTab{fresh, unknown}, 6*time.Hour, now))
+	assert.Equal(t, 7*time.Hour, Staleness(stale, now))
+	assert.Zero(t, Staleness(unknown, now))
+}
+
+func TestUnixMillis(t *testing.T) {
+	assert.Equal(t, int64(1758999500000), unixMillis(1758999500))
+	assert.Equal(t, int64(1758999500000), unixMillis(1758999500000))
+	assert.Zero(t, unixMillis(0))
+}
+
+func TestTabHealth(t *testing.T) {
+	tests := []struct {
+		name  string
+		tests []v1alpha1.TestResult
+		want  string
+		glyph string
+	}{
+		{name: "any failure", tests: []v1alpha1.TestResult{{FlakeCount: 2}, {FailureCount: 1}}, want: v1alpha1.FAILING_STATUS, glyph: "✗"},
+		{name: "only flakes", tests: []v1alpha1.TestResult{{FlakeCount: 2}, {}}, want: v1alpha1.FLAKY_STATUS, glyph: "⚠"},
+		{name: "no failure", tests: []v1alpha1.TestResult{{TotalRuns: 3}}, want: v1alpha1.PASSING_STATUS, glyph: "✓"},
+		{name: "no tests", want: v1alpha1.PASSING_STATUS, glyph: "✓"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tab := &v1alpha1.DashboardTab{TabState: v1alpha1.FAILING_STATUS, TestRuns: tt.tests}
+			assert.Equal(t, tt.want, TabHealth(tab))
+			assert.Equal(t, tt.glyph, HealthGlyph(tab))
+		})
+	}
+}
+
+func TestWidespread(t *testing.T) {
+	tab := &v1alpha1.DashboardTab{TestRuns: []v1alpha1.TestResult{
+		{TestName: "a", FailureCount: 3}, {TestName: "b", FailureCount: 1}, {TestName: "c", FlakeCount: 2}, {TestName: "d"},
+	}}
+	assert.Equal(t, 2, FailingTests(tab))
+	assert.True(t, Widespread(tab, 2))
+	assert.False(t, Widespread(tab, 3))
+	assert.False(t, Widespread(tab, 0))
+
+	// the failing tests left out by the limit per tab are counted
+	limited := &v1alpha1.DashboardTab{FailingTests: 20, HiddenTests: 17, TestRuns: tab.TestRuns[:3]}
+	assert.Equal(t, 20, FailingTests(limited))
+	assert.True(t, Widespread(limited, 5))
+}
+
+func TestFormatAge(t *testing.T) {
+	assert.Equal(t, "12m", FormatAge(12*time.Minute))
+	assert.Equal(t, "5h", FormatAge(5*time.Hour+30*time.Minute))
+	assert.Equal(t, "47h", FormatAge(47*time.Hour))
+	assert.Equal(t, "3d", FormatAge(80*time.Hour))
+}
+
+func TestExcludeLinkedBugs(t *testing.T) {
+	tests := []v1alpha1.TestResult{
+		{TestName: "triaged", LinkedBugs: []string{"https://github.com/kubernetes/kubernetes/issues/1"}},
+		{TestName: "untriaged"},
+		{TestName: "also triaged", LinkedBugs: []string{"b/2"}},
+	}
+	kept, linked := excludeLinkedBugs(tests)
+	assert.Equal(t, []v1alpha1.TestResult{{TestName: "untriaged"}}, kept)
+	assert.Equal(t, 2, linked)
+
+	kept, linked = excludeLinkedBugs([]v1alpha1.TestResult{})
+	assert.Empty(t, kept)
+	assert.Equal(t, 0, linked)
+}
+
+func TestRecoveredFlake(t *testing.T) {
+	runs := func(results ...string) []v1alpha1.RunResult {
+		var runResults []v1alpha1.RunResult
+		for _, result := range results {
+			runResults = append(runResults, v1alpha1.RunResult{Result: result})
+		}
+		return runResults
+	}
+	pass, fail, flaky, none := v1alpha1.RUN_PASSED, v1alpha1.RUN_FAILED, v1alpha1.RUN_FLAKY, v1alpha1.RUN_NO_RESULT
+	tests := []struct {
+		name string
+		test v1alpha1.TestResult
+		want bool
+	}{
+		{"recovered flake", v1alpha1.TestResult{FlakeCount: 1, RunResults: runs(pass, pass, pass, fail)}, true},
+		{"flaky run", v1alpha1.TestResult{FailureCount: 1, RunResults: runs(pass, pass, pass, flaky)}, true},
+		{"runs without result skipped", v1alpha1.TestResult{FlakeCount: 1, RunResults: runs(pass, none, pass, pass, fail)}, true},
+		{"flaked recently", v1alpha1.TestResult{FlakeCount: 1, RunResults: runs(pass, fail, pass, pass)}, false},
+		{"too few runs", v1alpha1.TestResult{FlakeCount: 1, RunResults: runs(pass, pass)}, false},
+		{"failure, not a flake", v1alpha1.TestResult{FailureCount: 1, RunResults: runs(pass, pass, pass, fail)}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, recoveredFlake(tt.test, 3))
+		})
+	}
+
+	kept, recovered := excludeRecoveredFlakes([]v1alpha1.TestResult{tests[0].test, tests[3].test}, 3)
+	assert.Equal(t, []v1alpha1.TestResult{tests[3].test}, kept)
+	assert.Equal(t, 1, recovered)
+}
+
+func TestLimitTests(t *testing.T) {
+	tests := []v1alpha1.TestResult{
+		{TestName: "once", FailureCount: 1},
+		{TestName: "often", FailureCount: 4},
+		{TestName: "flaky", FlakeCount: 4},
+		{TestName: "twice", FailureCount: 2},
+	}
+	cases := []struct {
+		name     string
+		limit    int
+		expected []string
+		hidden   int
+	}{
+		{name: "disabled", limit: 0, expected: []string{"once", "often", "flaky", "twice"}},
+		{name: "above the number of tests", limit: 10, expected: []string{"once", "often", "flaky", "twice"}},
+		{name: "most failing first, stable on ties", limit: 3, expected: []string{"often", "flaky", "twice"}, hidden: 1},
+		{name: "single test", limit: 1, expected: []string{"often"}, hidden: 3},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			limited, hidden := limitTests(append([]v1alpha1.TestResult(nil), tests...), tt.limit)
+			var names []string
+			for _, test := range limited {
+				names = append(names, test.TestName)
+			}
+			assert.Equal(t, tt.expected, names)
+			assert.Equal(t, tt.hidden, hidden)
+		})
+	}
+}
+
+func Test_SummarizeProgress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response interface{} = TestGroup{
+			Query:       "kubernetes-ci-logs/logs/ci-kubernetes-build",
+			Timestamps:  []int64{1758999193000},
+			Changelists: []string{"1972011571991285760"},
+			Tests:       []Test{{Name: "ci-kubernetes-build.Overall", ShortTexts: []string{"F"}, Messages: []string{"F"}}},
+		}
+		if strings.HasSuffix(r.URL.Path, "/summary") {
+			response = DashboardMapper{tabName: {OverallState: v1alpha1.FAILING_STATUS, DashboardName: dashboard}}
+		}
+		jsonData, _ := json.Marshal(response)
+		w.Write(jsonData) // nolint
+	}))
+	defer server.Close()
+
+	progress := make(chan ProgressEvent, 10)
+	tabs, err := NewTestGrid(server.URL).Summarize([]string{dashboard}, FilterOptions{}, progress)
+	assert.NoError(t, err)
+	assert.Len(t, tabs, 1)
+
+	var events []ProgressEvent
+	for event := range progress {
+		events = append(events, event)
+	}
+	assert.Len(t, events, 2)
+	assert.Equal(t, ProgressEvent{Dashboard: dashboard, Tabs: 1}, events[0])
+	assert.Equal(t, tabName, events[1].Tab)
+	assert.Equal(t, tabs[0], events[1].Result)
+}
+
+func Test_SummarizeExcludedTests(t *testing.T) {
+	const group = `{"query": "kubernetes-ci-logs/logs/ci-kubernetes-build", "timestamps": [1758999193000, 1758992000000, 1758985000000, 1758978000000],
+		"changelists": ["4", "3", "2", "1"], "tests": [%s]}`
+	tests := []struct {
+		name  string
+		state string
+		tests string
+		opts  FilterOptions
+		want  *v1alpha1.DashboardTab
+	}{
+		{
+			name:  "no test left",
+			state: v1alpha1.FAILING_STATUS,
+		},
+		{
+			name:  "every test linked",
+			state: v1alpha1.FAILING_STATUS,
+			tests: `{"name": "ci-kubernetes-build.Overall", "messages": ["F"], "short_texts": ["F"], "statuses": [{"count": 1, "value": 12}],
+				"linked_bugs": ["https://github.com/kubernetes/kubernetes/issues/1"]}`,
+			opts: FilterOptions{ExcludeLinkedBugs: true},
+			want: &v1alpha1.DashboardTab{LinkedTests: 1},
+		},
+		{
+			name:  "only failing test acknowledged",
+			state: v1alpha1.FAILING_STATUS,
+			tests: `{"name": "ci-kubernetes-build.Overall", "messages": ["F"], "short_texts": ["F"], "statuses": [{"count": 1, "value": 12}]}`,
+			opts:  FilterOptions{Acknowledged: Acknowledgments{AckKey(dashboard + "/" + tabName + "/ci-kubernetes-build.Overall"): {}}},
+			want:  &v1alpha1.DashboardTab{AcknowledgedTests: 1},
+		},
+		{
+			name:  "every flake recovered",
+			state: v1alpha1.FLAKY_STATUS,
+			tests: `{"name": "ci-kubernetes-build.Overall", "messages": ["", "", "", "F"], "short_texts": ["", "", "", "F"],
+				"statuses": [{"count": 3, "value": 1}, {"count": 1, "value": 12}]}`,
+			opts: FilterOptions{RecoveredRuns: 3},
+			want: &v1alpha1.DashboardTab{RecoveredTests: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if strings.HasSuffix(r.URL.Path, "/summary") {
+					jsonData, _ := json.Marshal(DashboardMapper{tabName: {OverallState: tt.state, DashboardName: dashboard}})
+					w.Write(jsonData) // nolint
+					return
+				}
+				fmt.Fprintf(w, group, tt.tests)
+			}))
+			defer server.Close()
+
+			tabs, err := NewTestGrid(server.URL).Summarize([]string{dashboard}, tt.opts, nil)
+			assert.NoError(t, err)
+			if tt.want == nil {
+				assert.Empty(t, tabs)
+				return
+			}
+			assert.Len(t, tabs, 1)
+			assert.Empty(t, tabs[0].TestRuns)
+			assert.Equal(t, tt.want.LinkedTests, tabs[0].LinkedTests)
+			assert.Equal(t, tt.want.AcknowledgedTests, tabs[0].AcknowledgedTests)
+			assert.Equal(t, tt.want.RecoveredTests, tabs[0].RecoveredTests)
+		})
+	}
+}
+
+func Test_SummarizeSummaryOnly(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/summary") {
+			t.Errorf("unexpected request of the tab tests %s", r.URL)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		jsonData, _ := json.Marshal(DashboardMapper{
+			"kind-master":  {OverallState: v1alpha1.FLAKY_STATUS, DashboardName: dashboard, CurrentState: " 8 of 10 (80.0%) recent columns passed "},
+			"build-master": {OverallState: v1alpha1.FAILING_STATUS, DashboardName: dashboard, LastUpdateTime: 1758999193},
+			"unit-master":  {OverallState: v1alpha1.PASSING_STATUS, DashboardName: dashboard},
+		})
+		w.Write(jsonData) // nolint
+	}))
+	defer server.Close()
+
+	tabs, err := NewTestGrid(server.URL).Summarize([]string{dashboard}, FilterOptions{SummaryOnly: true}, nil)
+	assert.NoError(t, err)
+	assert.Len(t, tabs, 2)
+	assert.Equal(t, dashboard+"#build-master", tabs[0].BoardHash)
+	assert.Equal(t, v1alpha1.FAILING_STATUS, tabs[0].TabState)
+	assert.Equal(t, int64(1758999193000), tabs[0].LastUpdated)
+	assert.Equal(t, "kind-master", tabs[1].TabName)
+	assert.Equal(t, "8 of 10 (80.0%) recent columns passed", tabs[1].StatusMessage)
+	assert.Empty(t, tabs[1].TestRuns)
+
+	tabs, err = NewTestGrid(server.URL).Summarize([]string{dashboard}, FilterOptions{SummaryOnly: true, IncludePassing: true}, nil)
+	assert.NoError(t, err)
+	assert.Len(t, tabs, 3)
+}
+
+func Test_SummarizePartial(t *testing.T) {
+	const unavailable = "sig-release-unavailable"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/"+unavailable) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var response interface{} = TestGroup{
+			Query:       "kubernetes-ci-logs/logs/ci-kubernetes-build",
+			Timestamps:  []int64{1758999193000},
+			Changelists: []string{"1972011571991285760"},
+			Tests:       []Test{{Name: "ci-kubernetes-build.Overall", ShortTexts: []string{"F"}, Messages: []string{"F"}}},
+		}
+		if strings.HasSuffix(r.URL.Path, "/summary") {
+			response = DashboardMapper{tabName: {OverallState: v1alpha1.FAILING_STATUS, DashboardName: dashboard}}
+		}
+		jsonData, _ := json.Marshal(response)
+		w.Write(jsonData) // nolint
+	}))
+	defer server.Close()
+
+	tabs, err := NewTestGrid(server.URL).Summarize([]string{unavailable, dashboard}, FilterOptions{}, nil)
+	assert.ErrorIs(t, err, ErrTestGridUnavailable)
+	assert.Contains(t, err.Error(), unavailable)
+	assert.Len(t, tabs, 1)
+	assert.Equal(t, dashboard, tabs[0].DashboardName)
+}
+
+func Test_SummarizeConcurrency(t *testing.T) {
+	const tabs = 6
+	var inFlight, maxInFlight atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response interface{} = TestGroup{
+			Query:       "kubernetes-ci-logs/logs/ci-kubernetes-build",
+			Timestamps:  []int64{1758999193000},
+			Changelists: []string{"1972011571991285760"},
+			Tests:       []Test{{Name: "ci-kubernetes-build.Overall", ShortTexts: []string{"F"}, Messages: []string{"F"}}},
+		}
+		if strings.HasSuffix(r.URL.Path, "/summary") {
+			summary := DashboardMapper{}
+			for i := range tabs {
+				summary[fmt.Sprintf("tab-%d", i)] = &v1alpha1.DashboardSummary{OverallState: v1alpha1.FAILING_STATUS, DashboardName: dashboard}
+			}
+			response = summary
+		} else {
+			current := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for previous := maxInFlight.Load(); current > previous && !maxInFlight.CompareAndSwap(previous, current); {
+				previous = maxInFlight.Load()
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		jsonData, _ := json.Marshal(response)
+		w.Write(jsonData) // nolint
+	}))
+	defer server.Close()
+
+	for _, concurrency := range []int{0, 1, 2} {
+		t.Run(strconv.Itoa(concurrency), func(t *testing.T) {
+			maxInFlight.Store(0)
+			tg := NewTestGrid(server.URL)
+			tg.Concurrency = concurrency
+			progress := make(chan ProgressEvent, tabs+1)
+			result, err := tg.Summarize([]string{dashboard}, FilterOptions{}, progress)
+			assert.NoError(t, err)
+			assert.Len(t, result, tabs)
+			assert.LessOrEqual(t, maxInFlight.Load(), int32(max(concurrency, 1)))
+
+			events := 0
+			for range progress {
+				events++
+			}
+			assert.Equal(t, tabs+1, events)
+		})
+	}
+}
+
+func Test_SummarizeTimeouts(t *testing.T) {
+	const slowTab, slowDashboard = "slow-tab", "sig-release-slow"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.String(), slowTab) || strings.HasPrefix(r.URL.Path, "/"+slowDashboard) {
+			time.Sleep(200 * time.Millisecond)
+		}
+		var response interface{} = TestGroup{
+			Timestamps: []int64{1758999193000},
+			Tests:      []Test{{Name: "ci-kubernetes-build.Overall", ShortTexts: []string{"F"}, Messages: []string{"F"}}},
+		}
+		if strings.HasSuffix(r.URL.Path, "/summary") {
+			response = DashboardMapper{
+				tabName: {OverallState: v1alpha1.FAILING_STATUS, DashboardName: dashboard},
+				slowTab: {OverallState: v1alpha1.FAILING_STATUS, DashboardName: dashboard},
+			}
+		}
+		jsonData, _ := json.Marshal(response)
+		w.Write(jsonData) // nolint
+	}))
+	defer server.Close()
+
+	t.Run("request timeout", func(t *testing.T) {
+		tg := NewTestGrid(server.URL)
+		tg.RequestTimeout = 50 * time.Millisecond
+		tabs, err := tg.Summarize([]string{dashboard}, FilterOptions{}, nil)
+		assert.ErrorIs(t, err, ErrTestGridUnavailable)
+		assert.NotErrorIs(t, err, ErrScrapeTimeout)
+		assert.Contains(t, err.Error(), "tab "+slowTab)
+		// the other tab is still fetched
+		assert.Len(t, tabs, 1)
+		assert.Equal(t, tabName, tabs[0].TabName)
+	})
+
+	t.Run("scrape timeout", func(t *testing.T) {
+		tg := NewTestGrid(server.URL)
+		tg.ScrapeTimeout = 50 * time.Millisecond
+		start := time.Now()
+		tabs, err := tg.Summarize([]string{slowDashboard, dashboard}, FilterOptions{}, nil)
+		assert.Less(t, time.Since(start), 200*time.Millisecond)
+		assert.ErrorIs(t, err, ErrScrapeTimeout)
+		assert.Equal(t, []string{slowDashboard, dashboard}, FailedDashboards(err))
+		assert.Empty(t, tabs)
+	})
+
+	t.Run("context deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		tg := NewTestGrid(server.URL)
+		tg.Context = ctx
+		// the run deadline is not reported as the scrape one
+		tg.ScrapeTimeout = time.Minute
+		start := time.Now()
+		tabs, err := tg.Summarize([]string{slowDashboard, dashboard}, FilterOptions{}, nil)
+		assert.Less(t, time.Since(start), 200*time.Millisecond)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.NotErrorIs(t, err, ErrScrapeTimeout)
+		assert.Equal(t, []string{slowDashboard, dashboard}, FailedDashboards(err))
+		assert.Empty(t, tabs)
+	})
+}
+
 func TestRenderStatuses(t *testing.T) {
 	message := "kubetest --timeout triggered"
 	tests := []struct {
@@ -169,6 +993,144 @@ func TestRenderStatuses(t *testing.T) {
 	}
 }
 
+func TestNewHTTPClient(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    ClientOptions
+		wantErr bool
+	}{
+		{name: "default options", opts: DefaultClientOptions},
+		{name: "zero timeout", opts: ClientOptions{MaxIdleConns: 1, KeepAlive: time.Second}, wantErr: true},
+		{name: "negative idle connections", opts: ClientOptions{Timeout: time.Second, MaxIdleConns: -1, KeepAlive: time.Second}, wantErr: true},
+		{name: "zero keepalive", opts: ClientOptions{Timeout: time.Second, MaxIdleConns: 1}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewHTTPClient(tt.opts)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.opts.Timeout, client.Timeout)
+			transport := client.Transport.(*userAgentTransport).base.(*http.Transport)
+			assert.Equal(t, tt.opts.MaxIdleConns, transport.MaxIdleConns)
+			assert.Equal(t, tt.opts.MaxIdleConns, transport.MaxIdleConnsPerHost)
+		})
+	}
+}
+
+func TestNewHTTPClientCACert(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	caCert := filepath.Join(dir, "ca.pem")
+	assert.NoError(t, os.WriteFile(caCert, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0o600))
+	notPEM := filepath.Join(dir, "ca.der")
+	assert.NoError(t, os.WriteFile(notPEM, server.Certificate().Raw, 0o600))
+
+	tests := []struct {
+		name       string
+		caCert     string
+		wantErr    string
+		wantGetErr bool
+	}{
+		{name: "system pool only", wantGetErr: true},
+		{name: "trusted bundle", caCert: caCert},
+		{name: "missing bundle", caCert: filepath.Join(dir, "missing.pem"), wantErr: "error reading the CA bundle"},
+		{name: "not a PEM bundle", caCert: notPEM, wantErr: "no PEM certificate found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultClientOptions
+			opts.CACert = tt.caCert
+			client, err := NewHTTPClient(opts)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			response, err := client.Get(server.URL)
+			if tt.wantGetErr {
+				assert.ErrorContains(t, err, "certificate")
+				return
+			}
+			assert.NoError(t, err)
+			response.Body.Close() // nolint:errcheck
+		})
+	}
+}
+
+func TestNewHTTPClientUserAgent(t *testing.T) {
+	var userAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	for _, want := range []string{DefaultClientOptions.UserAgent, "release-team-bot/1.0"} {
+		opts := DefaultClientOptions
+		opts.UserAgent, opts.Token = want, "secret"
+		client, err := NewHTTPClient(opts)
+		assert.NoError(t, err)
+		response, err := client.Get(server.URL)
+		assert.NoError(t, err)
+		response.Body.Close() // nolint
+		assert.Equal(t, want, userAgent)
+	}
+	assert.True(t, strings.HasPrefix(DefaultClientOptions.UserAgent, "signalhound/"))
+}
+
+func TestNewHTTPClientAuthentication(t *testing.T) {
+	tests := []struct {
+		name          string
+		token         string
+		basicAuth     string
+		authorization string
+		wantErr       bool
+	}{
+		{name: "public testgrid"},
+		{name: "bearer token", token: "secret", authorization: "Bearer secret"},
+		{name: "basic auth", basicAuth: "user:pass", authorization: "Basic dXNlcjpwYXNz"},
+		{name: "basic auth without password separator", basicAuth: "user", wantErr: true},
+		{name: "both credentials", token: "secret", basicAuth: "user:pass", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var authorization string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				authorization = r.Header.Get("Authorization")
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			opts := DefaultClientOptions
+			opts.Token, opts.BasicAuth = tt.token, tt.basicAuth
+			client, err := NewHTTPClient(opts)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			response, err := client.Get(server.URL)
+			assert.NoError(t, err)
+			response.Body.Close() // nolint
+			assert.Equal(t, tt.authorization, authorization)
+			if tt.token != "" {
+				assert.NotContains(t, opts.String(), tt.token)
+			}
+		})
+	}
+}
+
 func startServer(response interface{}) *httptest.Server {
 	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -176,3 +1138,88 @@ func startServer(response interface{}) *httptest.Server {
 		w.Write(jsonData) // nolint
 	}))
 }
+
+func TestFormatTestStatus(t *testing.T) {
+	timestamp := int64(1758960111000)
+	expected := fmt.Sprintf("\t%s %s %s\n", "F", time.Unix(timestamp/1000, 0), "kubetest --timeout triggered")
+	assert.Equal(t, expected, formatTestStatus("F", timestamp, "kubetest --timeout triggered"))
+}
+
+func TestExtractSIGs(t *testing.T) {
+	tests := []struct {
+		name     string
+		testName string
+		expected []string
+	}{
+		{name: "single sig", testName: "Kubernetes e2e suite.[It] [sig-node] Pods should restart", expected: []string{"node"}},
+		{name: "multiple sigs", testName: "[sig-api-machinery] [sig-cli] kubectl apply", expected: []string{"api-machinery", "cli"}},
+		{name: "no sig", testName: "ci-kubernetes-build.Overall"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ExtractSIGs(tt.testName))
+		})
+	}
+}
+
+// largeTestGroup builds a tab with thousands of failing e2e tests and history columns.
+func largeTestGroup(tests, columns int) *TestGroup {
+	testGroup := &TestGroup{Query: "kubernetes-ci-logs/logs/ci-kubernetes-e2e-gci-gce"}
+	for c := 0; c < columns; c++ {
+		testGroup.Timestamps = append(testGroup.Timestamps, 1758999193000-int64(c)*3600000)
+		testGroup.Changelists = append(testGroup.Changelists, strconv.Itoa(1972011571991285760-c))
+	}
+	for i := 0; i < tests; i++ {
+		test := Test{Name: fmt.Sprintf("Kubernetes e2e suite.[It] [sig-node] Pods should run test %d", i)}
+		for c := 0; c < columns; c++ {
+			shortText, message := "", ""
+			if c%3 == 0 {
+				shortText, message = "F", "timed out waiting for the condition"
+			}
+			test.ShortTexts = append(test.ShortTexts, shortText)
+			test.Messages = append(test.Messages, message)
+		}
+		testGroup.Tests = append(testGroup.Tests, test)
+	}
+	return testGroup
+}
+
+func BenchmarkFilterTabTests(b *testing.B) {
+	testGroup := largeTestGroup(5000, 30)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		filterTabTests(testGroup, v1alpha1.FAILING_STATUS, FilterOptions{MinFailure: 1})
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	results := []v1alpha1.RunResult{
+		{Result: v1alpha1.RUN_FAILED},
+		{Result: v1alpha1.RUN_FAILED},
+		{Result: v1alpha1.RUN_FLAKY},
+		{Result: v1alpha1.RUN_NO_RESULT},
+		{Result: v1alpha1.RUN_PASSED},
+	}
+	assert.Equal(t, "▁·▄██", Sparkline(results, 10))
+	assert.Equal(t, "▄██", Sparkline(results, 3))
+	assert.Empty(t, Sparkline(results, 0))
+	assert.Empty(t, Sparkline(nil, 10))
+	assert.Equal(t, "·", Sparkline([]v1alpha1.RunResult{{Result: "UNKNOWN"}}, 10))
+}
+
+func TestHistory(t *testing.T) {
+	results := []v1alpha1.RunResult{
+		{Result: v1alpha1.RUN_FAILED},
+		{Result: v1alpha1.RUN_FAILED},
+		{Result: v1alpha1.RUN_FLAKY},
+		{Result: v1alpha1.RUN_NO_RESULT},
+		{Result: v1alpha1.RUN_PASSED},
+	}
+	assert.Equal(t, ".-fxx", History(results, 10))
+	assert.Equal(t, "fxx", History(results, 3))
+	assert.Empty(t, History(results, 0))
+	assert.Empty(t, History(nil, 10))
+	assert.Equal(t, "-", History([]v1alpha1.RunResult{{Result: "UNKNOWN"}}, 10))
+}
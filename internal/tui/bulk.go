@@ -0,0 +1,226 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+	"sigs.k8s.io/signalhound/api/v1alpha1"
+	"sigs.k8s.io/signalhound/internal/github"
+	"sigs.k8s.io/signalhound/internal/testgrid"
+)
+
+// selectedMarker prefixes the tests selected for bulk filing in the tests panel.
+const selectedMarker = "● "
+
+// selectedTests are the tests selected for bulk filing by test key, preserved
+// across refreshes and tabs.
+var selectedTests = map[string]bool{}
+
+// testItemText renders the item of a test in the tests panel, marking the
+// tests selected for bulk filing and the tests already in the project.
+func testItemText(tab *v1alpha1.DashboardTab, test *v1alpha1.TestResult) string {
+	key := tab.TestKey(test)
+	text := tview.Escape(test.TestName)
+	if filedTests[key] {
+		text = filedMarker + text
+	}
+	if selectedTests[key] {
+		text = selectedMarker + text
+	}
+	return text
+}
+
+// selectedTab returns the tab listed in the tests panel, nil when none is.
+func selectedTab() *v1alpha1.DashboardTab {
+	for _, tab := range currentTabs {
+		if tab.BoardHash == selectedBoardHash {
+			return tab
+		}
+	}
+	return nil
+}
+
+// toggleTestSelection selects the current test of the tests panel for bulk
+// filing, or unselects it.
+func toggleTestSelection() {
+	tab := selectedTab()
+	i := brokenPanel.GetCurrentItem()
+	if tab == nil || i < 0 || i >= len(tab.TestRuns) {
+		return
+	}
+	test := &tab.TestRuns[i]
+	key := tab.TestKey(test)
+	if selectedTests[key] {
+		delete(selectedTests, key)
+	} else {
+		selectedTests[key] = true
+	}
+	brokenPanel.SetItemText(i, testItemText(tab, test), "")
+	position.SetText(fmt.Sprintf("[blue]%d [green]tests selected, press [blue]Ctrl-B [green]to file them", len(selectedTests)))
+}
+
+// bulkIssue is a selected test with its rendered draft issue
+type bulkIssue struct {
+	key     string
+	request github.DraftIssueRequest
+}
+
+// bulkSummary counts the selected tests by outcome of a bulk filing
+type bulkSummary struct {
+	created, linked, duplicates, belowSeverity, overLimit, failed int
+	// done are the keys of the tests created or already in the project, they
+	// are unselected once filed.
+	done []string
+}
+
+// String renders the summary in the position panel.
+func (s bulkSummary) String() string {
+	text := fmt.Sprintf("[blue]Created [yellow]%d DRAFT ISSUES[blue]", s.created)
+	var skipped []string
+	if s.duplicates > 0 {
+		skipped = append(skipped, fmt.Sprintf("%d already in the project", s.duplicates))
+	}
+	if s.linked > 0 {
+		skipped = append(skipped, fmt.Sprintf("%d with a linked bug", s.linked))
+	}
+	if s.belowSeverity > 0 {
+		skipped = append(skipped, fmt.Sprintf("%d below --file-min-severity", s.belowSeverity))
+	}
+	if s.overLimit > 0 {
+		skipped = append(skipped, fmt.Sprintf("%d over --max-issues, still selected", s.overLimit))
+	}
+	if len(skipped) > 0 {
+		text += ", skipped " + strings.Join(skipped, ", ")
+	}
+	if s.failed > 0 {
+		text += fmt.Sprintf(", [red]%d failed, still selected", s.failed)
+	}
+	return text
+}
+
+// fileSelectedTests creates the draft issues of the selected tests in the
+// background. Tests with a linked bug, below FileMinSeverity, or already in
+// the project when the fingerprint is enabled, are skipped, and at most
+// MaxIssues are created.
+func fileSelectedTests() {
+	if githubProject == nil {
+		position.SetText(fmt.Sprintf("[red]error: %v", errorMessage(github.ErrTokenMissing)))
+		return
+	}
+	if len(selectedTests) == 0 {
+		position.SetText("[yellow]No test selected, press [blue]Space [yellow]on a test to select it")
+		return
+	}
+
+	var summary bulkSummary
+	var issues []bulkIssue
+	for _, tab := range currentTabs {
+		for i := range tab.TestRuns {
+			test := &tab.TestRuns[i]
+			key := tab.TestKey(test)
+			if !selectedTests[key] {
+				continue
+			}
+			if len(test.LinkedBugs) > 0 {
+				summary.linked++
+				delete(selectedTests, key)
+				continue
+			}
+			if !testgrid.AtLeastSeverity(testgrid.SeverityLevel(tab.TabState, test), renderOptions.FileMinSeverity) {
+				summary.belowSeverity++
+				delete(selectedTests, key)
+				continue
+			}
+			title, body, err := renderIssue(tab, test)
+			if err != nil {
+				position.SetText(fmt.Sprintf("[red]error: %v", err.Error()))
+				return
+			}
+			issues = append(issues, bulkIssue{key: key, request: github.DraftIssueRequest{
+				Title: title, Body: body, Board: issueBoard(tab), Dashboard: tab.DashboardName,
+				FirstSeen: renderOptions.FirstSeen[key],
+			}})
+		}
+	}
+
+	position.SetText(fmt.Sprintf("[blue]Filing [yellow]%d DRAFT ISSUES[blue]…", len(issues)))
+	gh, fingerprint, maxIssues := githubProject, renderOptions.Fingerprint, renderOptions.MaxIssues
+	goSafe(func() {
+		var err error
+		if fingerprint && len(issues) > 0 {
+			issues, err = skipDuplicates(gh, issues, &summary)
+		}
+		if err == nil {
+			if maxIssues > 0 && len(issues) > maxIssues {
+				summary.overLimit = len(issues) - maxIssues
+				issues = issues[:maxIssues]
+			}
+			err = createIssues(gh, issues, &summary)
+		}
+		app.QueueUpdateDraw(func() {
+			for _, key := range summary.done {
+				delete(selectedTests, key)
+			}
+			// the done tests are created or already in the project
+			markFiled(summary.done...)
+			text := summary.String()
+			if err != nil {
+				text += fmt.Sprintf(": %v", errorMessage(err))
+			}
+			position.SetText(text)
+		})
+	})
+}
+
+// skipDuplicates drops the issues of the tests already fingerprinted in the
+// project, they are unselected.
+func skipDuplicates(gh github.ProjectManagerInterface, issues []bulkIssue, summary *bulkSummary) ([]bulkIssue, error) {
+	fingerprints, err := gh.ListFingerprints()
+	if err != nil {
+		return issues, err
+	}
+	kept := issues[:0]
+	for _, issue := range issues {
+		if _, ok := fingerprints[issue.key]; ok {
+			summary.duplicates++
+			summary.done = append(summary.done, issue.key)
+			continue
+		}
+		kept = append(kept, issue)
+	}
+	return kept, nil
+}
+
+// createIssues files the issues with a single batch, recording the created
+// drafts in the issues output file when set.
+func createIssues(gh github.ProjectManagerInterface, issues []bulkIssue, summary *bulkSummary) error {
+	requests := make([]github.DraftIssueRequest, len(issues))
+	for i, issue := range issues {
+		requests[i] = issue.request
+	}
+	drafts, err := gh.CreateDraftIssues(requests)
+	for i, draft := range drafts {
+		if draft == nil {
+			summary.failed++
+			continue
+		}
+		summary.created++
+		summary.done = append(summary.done, issues[i].key)
+		if renderOptions.IssuesOutput == "" {
+			continue
+		}
+		if recordErr := appendIssueRecord(renderOptions.IssuesOutput, issueRecord{
+			URL:       draft.URL,
+			ItemID:    draft.ItemID,
+			TestKey:   issues[i].key,
+			Title:     issues[i].request.Title,
+			Board:     issues[i].request.Board,
+			CreatedAt: time.Now().UTC(),
+		}); recordErr != nil && err == nil {
+			err = fmt.Errorf("draft issues created, but not written to %s: %w", renderOptions.IssuesOutput, recordErr)
+		}
+	}
+	return err
+}
@@ -0,0 +1,58 @@
+package tui
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/signalhound/internal/github"
+)
+
+// filedMarker prefixes the tests already in the project in the tests panel.
+const filedMarker = "📌 "
+
+// filedTests are the keys of the tests with an item in the project, from the
+// fingerprints of the project items, only accessed from the UI goroutine.
+var filedTests = map[string]bool{}
+
+// loadFiledTests cross-references the tests with the project items in the
+// background when the fingerprint is enabled, then marks the filed tests of
+// the tests panel.
+func loadFiledTests(gh github.ProjectManagerInterface) {
+	if gh == nil || !renderOptions.Fingerprint {
+		return
+	}
+	goSafe(func() {
+		fingerprints, err := gh.ListFingerprints()
+		app.QueueUpdateDraw(func() {
+			if err != nil {
+				position.SetText(fmt.Sprintf("[red]error: failed to list the filed tests: %v", errorMessage(err)))
+				return
+			}
+			filedTests = make(map[string]bool, len(fingerprints))
+			for key := range fingerprints {
+				filedTests[key] = true
+			}
+			redrawTestItems()
+		})
+	})
+}
+
+// markFiled marks the tests of the keys as filed, once their draft issue is created.
+func markFiled(keys ...string) {
+	for _, key := range keys {
+		filedTests[key] = true
+	}
+	redrawTestItems()
+}
+
+// redrawTestItems renders again the items of the tab listed in the tests panel.
+func redrawTestItems() {
+	tab := selectedTab()
+	if tab == nil {
+		return
+	}
+	for i := range tab.TestRuns {
+		if i < brokenPanel.GetItemCount() {
+			brokenPanel.SetItemText(i, testItemText(tab, &tab.TestRuns[i]), "")
+		}
+	}
+}
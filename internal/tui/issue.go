@@ -3,7 +3,15 @@ package tui
 import (
 	"bytes"
 	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
 	"text/template"
+	"time"
+
+	"sigs.k8s.io/signalhound/api/v1alpha1"
 )
 
 //go:embed template/*
@@ -20,6 +28,81 @@ type IssueTemplate struct {
 	ProwURL      string
 	ErrMessage   string
 	Sig          string
+	History      []HistoryRun
+	// Fingerprint is the hidden test key marker appended to the body, empty to omit it.
+	Fingerprint string
+	// Prefix is the kind of issue in the title, e.g. Failing Test.
+	Prefix string
+	// Vars are the user defined variables set with --template-var, e.g.
+	// {{.Vars.epic}} in the title or body templates.
+	Vars map[string]string
+}
+
+// templateVarPattern matches the keys of template variables, they must be
+// valid identifiers to be used as {{.Vars.key}}.
+var templateVarPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ValidateTemplateVars verifies every template variable key is an identifier.
+func ValidateTemplateVars(vars map[string]string) error {
+	for key := range vars {
+		if !templateVarPattern.MatchString(key) {
+			return fmt.Errorf("invalid template variable %q, keys must start with a letter or an underscore followed by letters, digits or underscores", key)
+		}
+	}
+	return nil
+}
+
+// HistoryRun is a run of the test rendered in the collapsible history block
+type HistoryRun struct {
+	Icon   string
+	Time   string
+	Result string
+}
+
+// runIcons are the icons of the run results in the history block
+var runIcons = map[string]string{
+	v1alpha1.RUN_PASSED:    "🟩",
+	v1alpha1.RUN_FAILED:    "🟥",
+	v1alpha1.RUN_FLAKY:     "🟪",
+	v1alpha1.RUN_NO_RESULT: "⬜",
+}
+
+// historyRuns returns the last runs of the test for the history block, up to
+// the limit, 0 disables the block.
+func historyRuns(results []v1alpha1.RunResult, limit int) []HistoryRun {
+	results = results[:min(limit, len(results))]
+	history := make([]HistoryRun, 0, len(results))
+	for _, result := range results {
+		history = append(history, HistoryRun{
+			Icon:   runIcons[result.Result],
+			Time:   time.Unix(result.Timestamp/1000, 0).UTC().Format(time.RFC1123),
+			Result: result.Result,
+		})
+	}
+	return history
+}
+
+// issueRecord is a line of the issues output file, one per created draft issue
+type issueRecord struct {
+	URL       string    `json:"url"`
+	ItemID    string    `json:"item_id"`
+	TestKey   string    `json:"test_key"`
+	Title     string    `json:"title"`
+	Board     string    `json:"board"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// appendIssueRecord appends the record to the file as a JSON line, creating the file if needed.
+func appendIssueRecord(path string, record issueRecord) error {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if err = json.NewEncoder(file).Encode(record); err != nil {
+		file.Close() // nolint:errcheck
+		return err
+	}
+	return file.Close()
 }
 
 func renderTemplate(issue *IssueTemplate, templateFile string) (output bytes.Buffer, err error) {
@@ -33,3 +116,12 @@ func renderTemplate(issue *IssueTemplate, templateFile string) (output bytes.Buf
 	}
 	return
 }
+
+// renderTitle renders the issue title template on a single line.
+func renderTitle(issue *IssueTemplate) (string, error) {
+	output, err := renderTemplate(issue, "template/title.tmpl")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(output.String()), nil
+}
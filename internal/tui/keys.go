@@ -0,0 +1,103 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+const helpPageName = "Help"
+
+// keyBindings are listed in the help overlay, in display order.
+var keyBindings = [][2]string{
+	{"↑/↓, k/j", "move up and down in the tabs and tests"},
+	{"g / G", "jump to the first or the last item"},
+	{"Ctrl-U / Ctrl-D", "scroll half a page up or down"},
+	{"Enter", "select the tab or the test"},
+	{"Space", "expand or collapse the selected tab"},
+	{"a", "expand or collapse every tab"},
+	{"Space (Tests)", "select the test for bulk filing"},
+	{"Ctrl-B (Tests)", "file draft issues for the selected tests"},
+	{"Esc", "go back to the previous panel"},
+	{"←/→", "switch between the Slack and GitHub panels"},
+	{"Ctrl-Space", "copy the focused message to the clipboard"},
+	{"Ctrl-B", "create a draft issue on the GitHub project"},
+	{"Ctrl-E", "show the sources of the last refresh error"},
+	{"Ctrl-S", "save the report to --output-file"},
+	{"?", "show this help, Esc to close it"},
+	{"Ctrl-C", "exit"},
+}
+
+// vimNavigation returns the input capture of a list translating the vim
+// motions to the list navigation, the other keys are passed through.
+func vimNavigation(list *tview.List) func(event *tcell.EventKey) *tcell.EventKey {
+	return func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyCtrlD:
+			scrollHalfPage(list, 1)
+			return nil
+		case tcell.KeyCtrlU:
+			scrollHalfPage(list, -1)
+			return nil
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case 'j':
+				return tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone)
+			case 'k':
+				return tcell.NewEventKey(tcell.KeyUp, 0, tcell.ModNone)
+			case 'g':
+				return tcell.NewEventKey(tcell.KeyHome, 0, tcell.ModNone)
+			case 'G':
+				return tcell.NewEventKey(tcell.KeyEnd, 0, tcell.ModNone)
+			}
+		}
+		return event
+	}
+}
+
+// scrollHalfPage moves the current item of the list by half its visible
+// height, in the direction of the sign, within the list bounds.
+func scrollHalfPage(list *tview.List, direction int) {
+	count := list.GetItemCount()
+	if count == 0 {
+		return
+	}
+	_, _, _, height := list.GetInnerRect()
+	item := list.GetCurrentItem() + direction*max(height/2, 1)
+	list.SetCurrentItem(min(max(item, 0), count-1))
+}
+
+// helpText renders the key bindings as lines of the same width, so they stay
+// aligned once centered in the modal.
+func helpText() string {
+	var lines []string
+	width := 0
+	for _, binding := range keyBindings {
+		line := fmt.Sprintf("%-16s %s", binding[0], binding[1])
+		width = max(width, len([]rune(line)))
+		lines = append(lines, line)
+	}
+	for i, line := range lines {
+		lines[i] = line + strings.Repeat(" ", width-len([]rune(line)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// showHelp opens the help overlay with the key bindings, closing it returns
+// the focus to the previously focused panel.
+func showHelp() {
+	if pages.HasPage(helpPageName) {
+		return
+	}
+	focused := app.GetFocus()
+	modal := tview.NewModal().
+		SetText(helpText()).
+		AddButtons([]string{"Close"}).
+		SetDoneFunc(func(int, string) {
+			pages.RemovePage(helpPageName)
+			app.SetFocus(focused)
+		})
+	pages.AddPage(helpPageName, modal, false, true)
+}
@@ -1,8 +1,10 @@
 package tui
 
 import (
-	"context"
+	"errors"
 	"fmt"
+	"math/rand/v2"
+	"os"
 	"os/exec"
 	"runtime"
 	"strings"
@@ -14,9 +16,13 @@ import (
 	"golang.org/x/text/language"
 	"sigs.k8s.io/signalhound/api/v1alpha1"
 	"sigs.k8s.io/signalhound/internal/github"
+	"sigs.k8s.io/signalhound/internal/testgrid"
 )
 
-const defaultPositionText = "[green]Select a content Windows and press [blue]Ctrl-Space [green]to COPY or press [blue]Ctrl-C [green]to exit"
+const (
+	defaultPositionText = "[green]Select a content Windows and press [blue]Ctrl-Space [green]to COPY or press [blue]Ctrl-C [green]to exit, [blue]? [green]for help"
+	errorsPageName      = "RefreshErrors"
+)
 
 var (
 	pagesName         = "SignalHound"
@@ -27,12 +33,96 @@ var (
 	slackPanel        = tview.NewTextArea()
 	githubPanel       = tview.NewTextArea()
 	position          = tview.NewTextView()
-	currentTabs       []*v1alpha1.DashboardTab // Store current tabs for refresh
-	githubToken       string                   // Store token for refresh
-	selectedBoardHash string                   // Store selected BoardHash for refresh preservation
-	selectedTestName  string                   // Store selected test name for refresh preservation
+	statusLine        = tview.NewTextView()
+	currentTabs       []*v1alpha1.DashboardTab       // Store current tabs for refresh
+	githubProject     github.ProjectManagerInterface // Store project manager for refresh
+	selectedBoardHash string                         // Store selected BoardHash for refresh preservation
+	selectedTestName  string                         // Store selected test name for refresh preservation
+	renderOptions     Options                        // Store the options used by the panels
+	refresh           refreshStatus                  // Store the state of the last refresh, only accessed from the UI goroutine
+	tabItems          []tabItem                      // Store the tab or test of each item of the tabs panel
+	expandedTabs      = map[string]bool{}            // Store the expanded tabs by BoardHash, preserved across refreshes
 )
 
+// refreshStatus holds the state rendered in the status line
+type refreshStatus struct {
+	// last is the time of the last successful refresh.
+	last time.Time
+	// next is the time of the upcoming refresh, zero when the refresh is disabled.
+	next time.Time
+	// err is the error returned by the last refresh, nil on success.
+	err error
+}
+
+// Options holds the optional settings of the TUI
+type Options struct {
+	// RefreshInterval is the period between RefreshFunc calls, 0 disables the refresh.
+	RefreshInterval time.Duration
+	// RefreshJitter randomizes each refresh period by up to plus or minus this
+	// duration, so several instances do not scrape in lockstep, 0 disables it.
+	RefreshJitter time.Duration
+	// RefreshFunc fetches the new dashboard tabs on each refresh, the tabs
+	// returned with an error are partial results and still rendered.
+	RefreshFunc func() ([]*v1alpha1.DashboardTab, error)
+	// FetchErr is the error of the initial fetch when tabs are partial results.
+	FetchErr error
+	// GitHubErr is a warning about the GitHub token, e.g. missing scopes,
+	// shown on the GitHub panel.
+	GitHubErr error
+	// NoColor renders the TUI without colors.
+	NoColor bool
+	// IssuesOutput is an optional file where each created draft issue is
+	// appended as a JSON line.
+	IssuesOutput string
+	// Fingerprint embeds the hidden test key of the test in the issue body,
+	// so the issue is matched again regardless of its title.
+	Fingerprint bool
+	// FirstSeen is when each test was first reported by the snapshots, by
+	// test key, stamped on the First Seen date field of the draft issues.
+	FirstSeen map[string]time.Time
+	// HistoryRuns is the number of recent runs listed in the history block of
+	// the issue body, 0 disables the block.
+	HistoryRuns int
+	// Board overrides the Testgrid Board of the draft issues, by default it is
+	// inferred from the dashboard of the test.
+	Board string
+	// TemplateVars are exposed as .Vars to the issue title and body templates.
+	TemplateVars map[string]string
+	// MaxIssues is the maximum number of draft issues created by a bulk
+	// filing of the selected tests, 0 disables the limit.
+	MaxIssues int
+	// FileMinSeverity is the least severe level, one of the testgrid
+	// SeverityLevels, of the tests filed as draft issues, whatever is shown.
+	// Empty files every test.
+	FileMinSeverity string
+	// MinTestsAffected marks the tabs with at least this number of distinct
+	// failing tests as a widespread breakage, 0 disables it.
+	MinTestsAffected int
+	// StatusMessages shows the status message TestGrid wrote for the tab in
+	// the header of an expanded tab.
+	StatusMessages bool
+	// MaxStaleness marks the tabs TestGrid did not update for longer as stale,
+	// 0 disables it.
+	MaxStaleness time.Duration
+	// SaveReport writes the report of the tabs, it is called on exit and on
+	// ctrl-s, nil disables it.
+	SaveReport func(tabs []*v1alpha1.DashboardTab) error
+}
+
+// tabsTitle renders the title of the tabs panel with the freshness of the
+// data, the age of the least recently updated tab.
+func tabsTitle(tabs []*v1alpha1.DashboardTab) string {
+	var oldest time.Duration
+	now := time.Now()
+	for _, tab := range tabs {
+		oldest = max(oldest, testgrid.Staleness(tab, now))
+	}
+	if oldest == 0 {
+		return formatTitle("Board#Tabs")
+	}
+	return formatTitle(fmt.Sprintf("Board#Tabs (oldest update %s ago)", testgrid.FormatAge(oldest)))
+}
+
 func formatTitle(txt string) string {
 	// var titleColor = "green"
 	// return fmt.Sprintf(" [%s:bg:b]%s[-:-:-] ", titleColor, txt)
@@ -59,6 +149,14 @@ func setPanelFocusStyle(p *tview.Box) {
 	app.SetFocus(p)
 }
 
+// tabItem is an item of the tabs panel, either a tab header or one of the
+// tests listed under an expanded tab.
+type tabItem struct {
+	tab *v1alpha1.DashboardTab
+	// test is the index of the test in the tab, -1 for the tab header.
+	test int
+}
+
 // updateTabsPanel updates the tabs panel with new data while preserving selection if possible.
 func updateTabsPanel(tabs []*v1alpha1.DashboardTab) {
 	if tabsPanel == nil {
@@ -68,8 +166,8 @@ func updateTabsPanel(tabs []*v1alpha1.DashboardTab) {
 	// Store current selection before clearing
 	if tabsPanel.GetItemCount() > 0 {
 		currentIndex := tabsPanel.GetCurrentItem()
-		if currentIndex >= 0 && currentIndex < len(currentTabs) {
-			selectedBoardHash = currentTabs[currentIndex].BoardHash
+		if currentIndex >= 0 && currentIndex < len(tabItems) {
+			selectedBoardHash = tabItems[currentIndex].tab.BoardHash
 			// Store selected test name if brokenPanel has items
 			if brokenPanel.GetItemCount() > 0 {
 				testIndex := brokenPanel.GetCurrentItem()
@@ -80,18 +178,47 @@ func updateTabsPanel(tabs []*v1alpha1.DashboardTab) {
 		}
 	}
 
-	// Clear and rebuild the tabs panel
+	// Clear and rebuild the tabs panel, keeping the callbacks by BoardHash for restoration
+	tabsPanel.SetTitle(tabsTitle(tabs))
+	tabCallbacks := renderTabItems(tabs)
+
+	// Update stored tabs
+	currentTabs = tabs
+
+	// Try to restore selection by BoardHash
+	if selectedBoardHash != "" {
+		if i := tabHeaderIndex(selectedBoardHash); i >= 0 {
+			tabsPanel.SetCurrentItem(i)
+			// Save test selection before callback clears it
+			savedTestName := selectedTestName
+			// Trigger the selection callback to restore brokenPanel
+			if callback, exists := tabCallbacks[selectedBoardHash]; exists {
+				callback()
+				// Restore test selection if it exists
+				if savedTestName != "" {
+					for j := 0; j < brokenPanel.GetItemCount(); j++ {
+						testName, _ := brokenPanel.GetItemText(j)
+						if testName == savedTestName {
+							brokenPanel.SetCurrentItem(j)
+							selectedTestName = savedTestName // Restore the stored value
+							break
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// renderTabItems clears the tabs panel and adds a header for each tab, followed
+// by its tests when the tab is expanded. It returns the selection callback of
+// each tab by BoardHash.
+func renderTabItems(tabs []*v1alpha1.DashboardTab) map[string]func() {
 	tabsPanel.Clear()
-	// Map to store tab selection callbacks by BoardHash for restoration
+	tabItems = tabItems[:0]
 	tabCallbacks := make(map[string]func())
 
 	for _, tab := range tabs {
-		icon := "🟣"
-		if tab.TabState == v1alpha1.FAILING_STATUS {
-			icon = "🔴"
-		}
-		tabText := fmt.Sprintf("[%s] %s", icon, strings.ReplaceAll(tab.BoardHash, "#", " - "))
-
 		// Create selection callback for this tab
 		tabCallback := func(tab *v1alpha1.DashboardTab) func() {
 			return func() {
@@ -100,16 +227,20 @@ func updateTabsPanel(tabs []*v1alpha1.DashboardTab) {
 				selectedTestName = "" // Clear test selection when tab changes
 
 				brokenPanel.Clear()
-				for _, test := range tab.TestRuns {
-					brokenPanel.AddItem(tview.Escape(test.TestName), "", 0, nil)
+				for i := range tab.TestRuns {
+					brokenPanel.AddItem(testItemText(tab, &tab.TestRuns[i]), "", 0, nil)
 				}
 				app.SetFocus(brokenPanel)
 				brokenPanel.SetCurrentItem(0)
+				if len(tab.TestRuns) > 0 {
+					brokenPanel.SetTitle(testsTitle(&tab.TestRuns[0]))
+				}
 				brokenPanel.SetChangedFunc(func(i int, testName string, secondaryText string, shortcut rune) {
 					position.SetText(defaultPositionText)
 					// Store the selected test name when user navigates tests
 					if i >= 0 && i < brokenPanel.GetItemCount() {
 						_, selectedTestName = brokenPanel.GetItemText(i)
+						brokenPanel.SetTitle(testsTitle(&tab.TestRuns[i]))
 					}
 				})
 				// Broken panel rendering the function selection
@@ -118,52 +249,135 @@ func updateTabsPanel(tabs []*v1alpha1.DashboardTab) {
 					selectedTestName = testName
 					var currentTest = tab.TestRuns[i]
 					updateSlackPanel(tab, &currentTest)
-					updateGitHubPanel(tab, &currentTest, githubToken)
+					updateGitHubPanel(tab, &currentTest, githubProject)
 					app.SetFocus(slackPanel)
 				})
 			}
 		}(tab)
 
 		tabCallbacks[tab.BoardHash] = tabCallback
-		tabsPanel.AddItem(tabText, "", 0, tabCallback)
+		tabsPanel.AddItem(tabHeaderText(tab, expandedTabs[tab.BoardHash]), "", 0, tabCallback)
+		tabItems = append(tabItems, tabItem{tab: tab, test: -1})
+		if !expandedTabs[tab.BoardHash] {
+			continue
+		}
+		// Selecting a test of an expanded tab opens the tab on that test
+		for i, test := range tab.TestRuns {
+			tabsPanel.AddItem("    └ "+tview.Escape(test.TestName), "", 0, func() {
+				tabCallback()
+				brokenPanel.SetCurrentItem(i)
+			})
+			tabItems = append(tabItems, tabItem{tab: tab, test: i})
+		}
 	}
+	return tabCallbacks
+}
 
-	// Update stored tabs
-	currentTabs = tabs
+// tabHeaderText renders the item of a tab, with the TestGrid state icon and the
+// glyph of the health of its tests, a collapsed tab shows its number of tests
+// with their failures and flakes.
+func tabHeaderText(tab *v1alpha1.DashboardTab, expanded bool) string {
+	icon := "🟣"
+	switch tab.TabState {
+	case v1alpha1.FAILING_STATUS:
+		icon = "🔴"
+	case v1alpha1.PASSING_STATUS:
+		icon = "🟢"
+	}
+	marker := "▸"
+	if expanded {
+		marker = "▾"
+	}
+	tabText := fmt.Sprintf("%s [%s] %s %s", marker, icon, testgrid.HealthGlyph(tab), strings.ReplaceAll(tab.BoardHash, "#", " - "))
+	if testgrid.Widespread(tab, renderOptions.MinTestsAffected) {
+		tabText += fmt.Sprintf(" [red](widespread: %d failing tests)[-]", testgrid.FailingTests(tab))
+	}
+	if !expanded {
+		failures := 0
+		for _, test := range tab.TestRuns {
+			failures += test.FailureCount + test.FlakeCount
+		}
+		tabText += fmt.Sprintf(" (%d tests, %d failures)", len(tab.TestRuns), failures)
+	} else if renderOptions.StatusMessages && tab.StatusMessage != "" {
+		tabText += " — " + tview.Escape(tab.StatusMessage)
+	}
+	if tab.HiddenTests > 0 {
+		tabText += fmt.Sprintf(" (…and %d more)", tab.HiddenTests)
+	}
+	if tab.LinkedTests > 0 {
+		tabText += fmt.Sprintf(" (%d with linked bugs)", tab.LinkedTests)
+	}
+	if tab.AcknowledgedTests > 0 {
+		tabText += fmt.Sprintf(" (%d acknowledged, hidden)", tab.AcknowledgedTests)
+	}
+	if tab.RecoveredTests > 0 {
+		tabText += fmt.Sprintf(" (%d recovered flakes, hidden)", tab.RecoveredTests)
+	}
+	if age := testgrid.Staleness(tab, time.Now()); renderOptions.MaxStaleness > 0 && age > renderOptions.MaxStaleness {
+		tabText += fmt.Sprintf(" [yellow](stale: updated %s ago)[-]", testgrid.FormatAge(age))
+	}
+	return tabText
+}
 
-	// Try to restore selection by BoardHash
-	if selectedBoardHash != "" {
-		for i, tab := range tabs {
-			if tab.BoardHash == selectedBoardHash {
-				tabsPanel.SetCurrentItem(i)
-				// Save test selection before callback clears it
-				savedTestName := selectedTestName
-				// Trigger the selection callback to restore brokenPanel
-				if callback, exists := tabCallbacks[selectedBoardHash]; exists {
-					callback()
-					// Restore test selection if it exists
-					if savedTestName != "" {
-						for j := 0; j < brokenPanel.GetItemCount(); j++ {
-							testName, _ := brokenPanel.GetItemText(j)
-							if testName == savedTestName {
-								brokenPanel.SetCurrentItem(j)
-								selectedTestName = savedTestName // Restore the stored value
-								break
-							}
-						}
-					}
-				}
-				break
-			}
+// tabHeaderIndex returns the index of the tab header in the tabs panel, -1 when
+// the tab is not listed.
+func tabHeaderIndex(boardHash string) int {
+	for i, item := range tabItems {
+		if item.test < 0 && item.tab.BoardHash == boardHash {
+			return i
+		}
+	}
+	return -1
+}
+
+// toggleTab expands or collapses the tab of the selected item, keeping the
+// selection on its header.
+func toggleTab() {
+	index := tabsPanel.GetCurrentItem()
+	if index < 0 || index >= len(tabItems) {
+		return
+	}
+	boardHash := tabItems[index].tab.BoardHash
+	expandedTabs[boardHash] = !expandedTabs[boardHash]
+	renderTabItems(currentTabs)
+	tabsPanel.SetCurrentItem(tabHeaderIndex(boardHash))
+}
+
+// toggleAllTabs expands every tab when one of them is collapsed, otherwise it
+// collapses them all, keeping the selection on the header of the current tab.
+func toggleAllTabs() {
+	var boardHash string
+	if index := tabsPanel.GetCurrentItem(); index >= 0 && index < len(tabItems) {
+		boardHash = tabItems[index].tab.BoardHash
+	}
+	expand := false
+	for _, tab := range currentTabs {
+		if !expandedTabs[tab.BoardHash] {
+			expand = true
+			break
 		}
 	}
+	for _, tab := range currentTabs {
+		expandedTabs[tab.BoardHash] = expand
+	}
+	renderTabItems(currentTabs)
+	tabsPanel.SetCurrentItem(max(tabHeaderIndex(boardHash), 0))
 }
 
 // RenderVisual loads the entire grid and componnents in the app.
-// this is a blocking functions.
-func RenderVisual(tabs []*v1alpha1.DashboardTab, token string, refreshInterval time.Duration, refreshFunc func() ([]*v1alpha1.DashboardTab, error)) error {
+// this is a blocking functions. A nil project manager disables the
+// draft issue creation. A panic restores the terminal and is returned
+// as an error.
+func RenderVisual(tabs []*v1alpha1.DashboardTab, gh github.ProjectManagerInterface, opts Options) (err error) {
+	defer recoverPanic(&err)
 	app = tview.NewApplication()
-	githubToken = token
+	if opts.NoColor {
+		if screen, err := newMonochromeScreen(); err == nil {
+			app.SetScreen(screen)
+		}
+	}
+	githubProject = gh
+	renderOptions = opts
 	currentTabs = tabs
 
 	// Render tab in the first row
@@ -172,7 +386,22 @@ func RenderVisual(tabs []*v1alpha1.DashboardTab, token string, refreshInterval t
 	tabsPanel.SetSelectedBackgroundColor(tcell.ColorBlue)
 	tabsPanel.SetHighlightFullLine(true)
 	tabsPanel.SetMainTextStyle(tcell.StyleDefault)
-	tabsPanel.SetTitle(formatTitle("Board#Tabs"))
+	tabsPanel.SetTitle(tabsTitle(tabs))
+	// space expands or collapses the selected tab, and a expands or collapses every tab
+	navigation := vimNavigation(tabsPanel)
+	tabsPanel.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyRune {
+			switch event.Rune() {
+			case ' ':
+				toggleTab()
+				return nil
+			case 'a':
+				toggleAllTabs()
+				return nil
+			}
+		}
+		return navigation(event)
+	})
 
 	// Broken tests in the tab
 	brokenPanel.ShowSecondaryText(false).SetDoneFunc(func() { app.SetFocus(tabsPanel) })
@@ -181,6 +410,19 @@ func RenderVisual(tabs []*v1alpha1.DashboardTab, token string, refreshInterval t
 	brokenPanel.SetSelectedBackgroundColor(tcell.ColorBlue)
 	brokenPanel.SetHighlightFullLine(true)
 	brokenPanel.SetMainTextStyle(tcell.StyleDefault)
+	// space selects the test for bulk filing, and ctrl-b files the selected tests
+	testsNavigation := vimNavigation(brokenPanel)
+	brokenPanel.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyCtrlB {
+			fileSelectedTests()
+			return nil
+		}
+		if event.Key() == tcell.KeyRune && event.Rune() == ' ' {
+			toggleTestSelection()
+			return nil
+		}
+		return testsNavigation(event)
+	})
 
 	// Slack Final issue rendering
 	setPanelDefaultStyle(slackPanel.Box)
@@ -190,41 +432,66 @@ func RenderVisual(tabs []*v1alpha1.DashboardTab, token string, refreshInterval t
 
 	// GitHub panel rendering
 	setPanelDefaultStyle(githubPanel.Box)
-	githubPanel.SetTitle(formatTitle("Github Issue"))
+	switch {
+	case gh == nil:
+		githubPanel.SetTitle(formatTitle("Github Issue (draft creation disabled: no GitHub token)"))
+	case opts.GitHubErr != nil:
+		githubPanel.SetTitle(formatTitle(fmt.Sprintf("Github Issue (warning: %s)", errorMessage(opts.GitHubErr))))
+	default:
+		githubPanel.SetTitle(formatTitle("Github Issue"))
+	}
 	githubPanel.SetWrap(true).SetDisabled(true)
 	githubPanel.SetTextStyle(tcell.StyleDefault)
 
 	// Final position bottom panel for information
 	position.SetDynamicColors(true).SetTextAlign(tview.AlignCenter).SetText(defaultPositionText).SetTextStyle(tcell.StyleDefault)
 
+	// Status line with the refresh state under the position panel
+	refresh = refreshStatus{last: time.Now(), err: opts.FetchErr}
+	wait := jitteredInterval(opts.RefreshInterval, opts.RefreshJitter)
+	if opts.RefreshInterval > 0 && opts.RefreshFunc != nil {
+		refresh.next = refresh.last.Add(wait)
+	}
+	statusLine.SetDynamicColors(true).SetTextAlign(tview.AlignCenter).SetTextStyle(tcell.StyleDefault)
+	updateStatusLine()
+
 	// Create the grid layout
-	grid := tview.NewGrid().SetRows(10, 10, 0, 0, 1).
+	grid := tview.NewGrid().SetRows(10, 10, 0, 0, 1, 1).
 		AddItem(tabsPanel, 0, 0, 1, 2, 0, 0, true).
 		AddItem(brokenPanel, 1, 0, 1, 2, 0, 0, false).
-		AddItem(position, 4, 0, 1, 2, 0, 0, false)
+		AddItem(position, 4, 0, 1, 2, 0, 0, false).
+		AddItem(statusLine, 5, 0, 1, 2, 0, 0, false)
 
 	// Adding middle panel and split across rows and columns
 	grid.AddItem(slackPanel, 2, 0, 2, 1, 0, 0, false).
 		AddItem(githubPanel, 2, 1, 2, 1, 0, 0, false)
 
-	// Initial tabs setup
+	// Initial tabs setup, the filed tests are marked once listed
 	updateTabsPanel(tabs)
+	loadFiledTests(gh)
 
 	// Set up periodic refresh if interval is configured and refresh function is provided
-	if refreshInterval > 0 && refreshFunc != nil {
-		go func() {
-			ticker := time.NewTicker(refreshInterval)
-			defer ticker.Stop()
-			for range ticker.C {
-				newTabs, err := refreshFunc()
-				if err != nil {
+	if opts.RefreshInterval > 0 && opts.RefreshFunc != nil {
+		goSafe(func() {
+			for {
+				time.Sleep(wait)
+				newTabs, err := opts.RefreshFunc()
+				wait = jitteredInterval(opts.RefreshInterval, opts.RefreshJitter)
+				next := time.Now().Add(wait)
+				if err != nil && len(newTabs) == 0 {
 					app.QueueUpdateDraw(func() {
-						position.SetText(fmt.Sprintf("[red]Refresh error: %v", err))
+						refresh.err, refresh.next = err, next
+						updateStatusLine()
+						position.SetText(fmt.Sprintf("[red]Refresh error: %v", errorMessage(err)))
 					})
 					continue
 				}
 				app.QueueUpdateDraw(func() {
+					// partial results are rendered, the failed sources are listed with ctrl-e
+					refresh = refreshStatus{last: time.Now(), next: next, err: err}
+					updateStatusLine()
 					updateTabsPanel(newTabs)
+					loadFiledTests(githubProject)
 					position.SetText(fmt.Sprintf("[green]Refreshed at %s", time.Now().Format("15:04:05")))
 					// Clear refresh message after 1 seconds
 					go func() {
@@ -235,20 +502,81 @@ func RenderVisual(tabs []*v1alpha1.DashboardTab, token string, refreshInterval t
 					}()
 				})
 			}
-		}()
+		})
+
+		// Tick the countdown of the status line
+		goSafe(func() {
+			ticker := time.NewTicker(time.Second)
+			defer ticker.Stop()
+			for range ticker.C {
+				app.QueueUpdateDraw(updateStatusLine)
+			}
+		})
 	}
 
+	// ctrl-e opens the details of the last refresh error, ctrl-s saves the
+	// report and ? the key bindings from any panel.
+	app.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyCtrlE {
+			showRefreshErrors()
+			return nil
+		}
+		if event.Key() == tcell.KeyCtrlS && opts.SaveReport != nil {
+			if err := opts.SaveReport(currentTabs); err != nil {
+				position.SetText(fmt.Sprintf("[red]error: %v", errorMessage(err)))
+			} else {
+				position.SetText("[blue]SAVED [yellow]REPORT [blue]TO THE OUTPUT FILE!")
+			}
+			return nil
+		}
+		if event.Key() == tcell.KeyRune && event.Rune() == '?' {
+			showHelp()
+			return nil
+		}
+		return event
+	})
+
 	// Render the final page.
 	pages = tview.NewPages().AddPage(pagesName, grid, true, true)
-	return app.SetRoot(pages, true).EnableMouse(true).Run()
+	if err = app.SetRoot(pages, true).EnableMouse(true).Run(); err != nil {
+		return err
+	}
+	// the last rendered tabs are saved on exit
+	var saveErr error
+	if opts.SaveReport != nil {
+		if saveErr = opts.SaveReport(currentTabs); saveErr != nil {
+			saveErr = fmt.Errorf("failed to save the report: %w", saveErr)
+		}
+	}
+	if err = goroutinePanic(); err != nil {
+		return err
+	}
+	return saveErr
+}
+
+// newMonochromeScreen returns a terminal screen ignoring every color, built from
+// the terminfo of $TERM with no colors available.
+func newMonochromeScreen() (tcell.Screen, error) {
+	ti, err := tcell.LookupTerminfo(os.Getenv("TERM"))
+	if err != nil {
+		return nil, err
+	}
+	monochrome := *ti
+	monochrome.Colors = 0
+	return tcell.NewTerminfoScreenFromTtyTerminfo(nil, &monochrome)
 }
 
 // updateSlackPanel writes down to left panel (Slack) content.
 func updateSlackPanel(tab *v1alpha1.DashboardTab, currentTest *v1alpha1.TestResult) {
 	// set the item string with current test content
-	item := fmt.Sprintf("%s %s on [%s](%s): `%s` [Prow](%s), [Triage](%s), last failure on %s\n",
+	// the prow link is left out when TestGrid has no build of the failing run
+	var prowLink string
+	if currentTest.ProwJobURL != "" {
+		prowLink = fmt.Sprintf("[Prow](%s), ", currentTest.ProwJobURL)
+	}
+	item := fmt.Sprintf("%s %s on [%s](%s): `%s` %s[Triage](%s), last failure on %s\n",
 		tab.StateIcon, cases.Title(language.English).String(tab.TabState), tab.BoardHash, tab.TabURL,
-		currentTest.TestName, currentTest.ProwJobURL, currentTest.TriageURL, timeClean(currentTest.LatestTimestamp),
+		currentTest.TestName, prowLink, currentTest.TriageURL, timeClean(currentTest.LatestTimestamp),
 	)
 
 	// set input capture, ctrl-space for clipboard copy, esc to cancel panel selection.
@@ -283,8 +611,8 @@ func updateSlackPanel(tab *v1alpha1.DashboardTab, currentTest *v1alpha1.TestResu
 	})
 }
 
-// updateGitHubPanel writes down to the right panel (GitHub) content.
-func updateGitHubPanel(tab *v1alpha1.DashboardTab, currentTest *v1alpha1.TestResult, token string) {
+// renderIssue renders the title and the body of the draft issue of the test.
+func renderIssue(tab *v1alpha1.DashboardTab, currentTest *v1alpha1.TestResult) (title, body string, err error) {
 	// create the filled-out issue template object
 	splitBoard := strings.Split(tab.BoardHash, "#")
 	issue := &IssueTemplate{
@@ -297,6 +625,11 @@ func updateGitHubPanel(tab *v1alpha1.DashboardTab, currentTest *v1alpha1.TestRes
 		ErrMessage:   currentTest.ErrorMessage,
 		FirstFailure: timeClean(currentTest.FirstTimestamp),
 		LastFailure:  timeClean(currentTest.LatestTimestamp),
+		History:      historyRuns(currentTest.RunResults, renderOptions.HistoryRuns),
+		Vars:         renderOptions.TemplateVars,
+	}
+	if renderOptions.Fingerprint {
+		issue.Fingerprint = github.Fingerprint(tab.TestKey(currentTest))
 	}
 
 	// pick the correct template by failure status
@@ -304,13 +637,32 @@ func updateGitHubPanel(tab *v1alpha1.DashboardTab, currentTest *v1alpha1.TestRes
 	if tab.TabState == v1alpha1.FAILING_STATUS {
 		templateFile, prefixTitle = "template/failure.tmpl", "Failing Test"
 	}
+	issue.Prefix = prefixTitle
 	template, err := renderTemplate(issue, templateFile)
+	if err != nil {
+		return "", "", err
+	}
+	if title, err = renderTitle(issue); err != nil {
+		return "", "", err
+	}
+	return title, template.String(), nil
+}
+
+// issueBoard returns the Testgrid Board of the draft issues of the tab.
+func issueBoard(tab *v1alpha1.DashboardTab) string {
+	if renderOptions.Board != "" {
+		return renderOptions.Board
+	}
+	return tab.DashboardName
+}
+
+// updateGitHubPanel writes down to the right panel (GitHub) content.
+func updateGitHubPanel(tab *v1alpha1.DashboardTab, currentTest *v1alpha1.TestResult, gh github.ProjectManagerInterface) {
+	issueTitle, issueBody, err := renderIssue(tab, currentTest)
 	if err != nil {
 		position.SetText(fmt.Sprintf("[red]error: %v", err.Error()))
 		return
 	}
-	issueBody := template.String()
-	issueTitle := fmt.Sprintf("[%v] %v", prefixTitle, currentTest.TestName)
 	githubPanel.SetText(issueBody, false)
 
 	// set input capture, ctrl-space for clipboard copy, ctrl-b for
@@ -334,12 +686,43 @@ func updateGitHubPanel(tab *v1alpha1.DashboardTab, currentTest *v1alpha1.TestRes
 			}()
 		}
 		if event.Key() == tcell.KeyCtrlB {
-			gh := github.NewProjectManager(context.Background(), token)
-			if err := gh.CreateDraftIssue(issueTitle, issueBody, tab.BoardHash); err != nil {
-				position.SetText(fmt.Sprintf("[red]error: %v", err.Error()))
+			if gh == nil {
+				position.SetText(fmt.Sprintf("[red]error: %v", errorMessage(github.ErrTokenMissing)))
 				return event
 			}
+			// the test is already triaged, do not file a duplicate
+			if len(currentTest.LinkedBugs) > 0 {
+				position.SetText(fmt.Sprintf("[yellow]Already tracked in TestGrid by %s, no draft issue created", strings.Join(currentTest.LinkedBugs, ", ")))
+				return event
+			}
+			if level := testgrid.SeverityLevel(tab.TabState, currentTest); !testgrid.AtLeastSeverity(level, renderOptions.FileMinSeverity) {
+				position.SetText(fmt.Sprintf("[yellow]Severity %s is below --file-min-severity %s, no draft issue created", level, renderOptions.FileMinSeverity))
+				return event
+			}
+			board := issueBoard(tab)
+			drafts, err := gh.CreateDraftIssues([]github.DraftIssueRequest{{
+				Title: issueTitle, Body: issueBody, Board: board, Dashboard: tab.DashboardName,
+				FirstSeen: renderOptions.FirstSeen[tab.TestKey(currentTest)],
+			}})
+			if err != nil {
+				position.SetText(fmt.Sprintf("[red]error: %v", errorMessage(err)))
+				return event
+			}
+			draft := drafts[0]
+			markFiled(tab.TestKey(currentTest))
 			position.SetText("[blue]Created [yellow]DRAFT ISSUE [blue] on GitHub Project!")
+			if renderOptions.IssuesOutput != "" {
+				if err := appendIssueRecord(renderOptions.IssuesOutput, issueRecord{
+					URL:       draft.URL,
+					ItemID:    draft.ItemID,
+					TestKey:   tab.TestKey(currentTest),
+					Title:     issueTitle,
+					Board:     board,
+					CreatedAt: time.Now().UTC(),
+				}); err != nil {
+					position.SetText(fmt.Sprintf("[red]draft issue created, but not written to %s: %v", renderOptions.IssuesOutput, err))
+				}
+			}
 			setPanelFocusStyle(githubPanel.Box)
 			go func() {
 				app.QueueUpdateDraw(func() {
@@ -363,6 +746,87 @@ func updateGitHubPanel(tab *v1alpha1.DashboardTab, currentTest *v1alpha1.TestRes
 	})
 }
 
+// jitteredInterval returns the refresh interval moved by a random duration
+// between -jitter and +jitter, never below a second.
+func jitteredInterval(interval, jitter time.Duration) time.Duration {
+	if jitter <= 0 || interval <= 0 {
+		return interval
+	}
+	return max(interval+time.Duration(rand.Int64N(int64(2*jitter)+1))-jitter, time.Second)
+}
+
+// sparklineRuns is the number of recent runs of the sparkline of the tests panel.
+const sparklineRuns = 20
+
+// testsTitle renders the title of the tests panel with the rates of the
+// selected test, and the sparkline of its recent runs.
+func testsTitle(test *v1alpha1.TestResult) string {
+	if test.TotalRuns == 0 {
+		return formatTitle("Tests")
+	}
+	title := fmt.Sprintf("Tests (failure rate %.0f%%, flake rate %.0f%% over %d runs)",
+		test.FailureRate()*100, test.FlakeRate()*100, test.TotalRuns)
+	if spark := testgrid.Sparkline(test.RunResults, sparklineRuns); spark != "" {
+		title += " " + spark
+	}
+	return formatTitle(title)
+}
+
+// errorMessage returns a tailored message for the known failure classes.
+func errorMessage(err error) string {
+	switch {
+	case errors.Is(err, github.ErrTokenMissing):
+		return "GitHub token required, set SIGNALHOUND_GITHUB_TOKEN or GITHUB_TOKEN"
+	case errors.Is(err, github.ErrProjectNotFound):
+		return fmt.Sprintf("GitHub project not found, check --project-id (%v)", err)
+	case errors.Is(err, github.ErrFieldNotResolved):
+		return fmt.Sprintf("project board field not resolved (%v)", err)
+	case errors.Is(err, testgrid.ErrTestGridUnavailable):
+		return fmt.Sprintf("TestGrid unavailable, retrying on the next refresh (%v)", err)
+	}
+	return err.Error()
+}
+
+// statusText renders the status line for the refresh state at the given time.
+func statusText(status refreshStatus, now time.Time) string {
+	text := fmt.Sprintf("[green]Last refresh: [white]%s", status.last.Format("15:04:05"))
+	if status.next.IsZero() {
+		text += " [green]| auto-refresh disabled"
+	} else {
+		countdown := status.next.Sub(now).Round(time.Second)
+		if countdown < 0 {
+			countdown = 0
+		}
+		text += fmt.Sprintf(" [green]| next refresh in [white]%s", countdown)
+	}
+	if status.err != nil {
+		text += " [red::b] LAST REFRESH FAILED [-:-:-] [green]press [blue]Ctrl-E [green]for the unavailable sources"
+	}
+	return text
+}
+
+// updateStatusLine redraws the status line with the current refresh state.
+func updateStatusLine() {
+	statusLine.SetText(statusText(refresh, time.Now()))
+}
+
+// showRefreshErrors opens a modal with the details of the last refresh error,
+// closing it returns the focus to the previously focused panel.
+func showRefreshErrors() {
+	if refresh.err == nil || pages.HasPage(errorsPageName) {
+		return
+	}
+	focused := app.GetFocus()
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("Last refresh failed\n\n%s", errorMessage(refresh.err))).
+		AddButtons([]string{"Close"}).
+		SetDoneFunc(func(int, string) {
+			pages.RemovePage(errorsPageName)
+			app.SetFocus(focused)
+		})
+	pages.AddPage(errorsPageName, modal, false, true)
+}
+
 // timeClean returns the string representation of the timestamp.
 func timeClean(ts int64) string {
 	return time.Unix(ts/1000, 0).UTC().Format(time.RFC1123)
@@ -0,0 +1,94 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"sigs.k8s.io/signalhound/internal/github"
+)
+
+// pickerItem is a field or a single select option listed by the picker
+type pickerItem struct {
+	name, id string
+}
+
+// fieldOptions returns the options of the field sorted by name.
+func fieldOptions(field github.ProjectFieldInfo) []pickerItem {
+	options := make([]pickerItem, 0, len(field.Options))
+	for name, id := range field.Options {
+		options = append(options, pickerItem{name: name, id: fmt.Sprintf("%v", id)})
+	}
+	sort.Slice(options, func(i, j int) bool { return options[i].name < options[j].name })
+	return options
+}
+
+// PickField browses the project fields and their options interactively and
+// returns the ID of the selected field or option, Enter on a field without
+// options picks the field itself. The ID is empty when the picker is closed
+// with Esc or Ctrl-C without a selection.
+func PickField(fields []github.ProjectFieldInfo) (string, error) {
+	picker := tview.NewApplication()
+	fieldsList := tview.NewList().ShowSecondaryText(true)
+	optionsList := tview.NewList().ShowSecondaryText(true)
+	help := tview.NewTextView().SetDynamicColors(true).
+		SetText("[green]Press [blue]Enter [green]to pick the field or option ID, [blue]Esc [green]to go back, [blue]Ctrl-C [green]to exit")
+	for _, list := range []*tview.List{fieldsList, optionsList} {
+		setPanelDefaultStyle(list.Box)
+		list.SetInputCapture(vimNavigation(list))
+	}
+	fieldsList.SetTitle(formatTitle("Fields"))
+	optionsList.SetTitle(formatTitle("Options"))
+
+	var picked string
+	showOptions := func(field github.ProjectFieldInfo) {
+		optionsList.Clear()
+		for _, option := range fieldOptions(field) {
+			optionsList.AddItem(tview.Escape(option.name), option.id, 0, func() {
+				picked = option.id
+				picker.Stop()
+			})
+		}
+	}
+	for _, field := range fields {
+		fieldID := fmt.Sprintf("%v", field.ID)
+		secondary := fmt.Sprintf("%s  %s", field.DataType, fieldID)
+		fieldsList.AddItem(tview.Escape(string(field.Name)), secondary, 0, func() {
+			if len(field.Options) == 0 {
+				picked = fieldID
+				picker.Stop()
+				return
+			}
+			picker.SetFocus(optionsList)
+		})
+	}
+	fieldsList.SetChangedFunc(func(i int, _, _ string, _ rune) {
+		showOptions(fields[i])
+	})
+	if len(fields) > 0 {
+		showOptions(fields[0])
+	}
+
+	picker.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			if optionsList.HasFocus() {
+				picker.SetFocus(fieldsList)
+			} else {
+				picker.Stop()
+			}
+			return nil
+		}
+		return event
+	})
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(tview.NewFlex().
+			AddItem(fieldsList, 0, 1, true).
+			AddItem(optionsList, 0, 1, false), 0, 1, true).
+		AddItem(help, 1, 0, false)
+	if err := picker.SetRoot(layout, true).EnableMouse(true).Run(); err != nil {
+		return "", err
+	}
+	return picked, nil
+}
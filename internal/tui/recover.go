@@ -0,0 +1,55 @@
+package tui
+
+import (
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"sync"
+)
+
+var (
+	panicMu  sync.Mutex
+	panicErr error // Store the first panic of a TUI goroutine, returned by RenderVisual
+)
+
+// recoverPanic turns a panic of the TUI into an error once the terminal is
+// restored, tview finalizes the screen before re-panicking from Run.
+func recoverPanic(err *error) {
+	if r := recover(); r != nil {
+		*err = panicError(r)
+		if app != nil {
+			app.Stop()
+		}
+	}
+}
+
+// goSafe runs the function in a goroutine, a panic stops the application and
+// is returned by RenderVisual instead of crashing with the terminal in raw mode.
+func goSafe(fn func()) {
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				panicMu.Lock()
+				if panicErr == nil {
+					panicErr = panicError(r)
+				}
+				panicMu.Unlock()
+				app.Stop()
+			}
+		}()
+		fn()
+	}()
+}
+
+// goroutinePanic returns the panic recorded by goSafe, if any.
+func goroutinePanic() error {
+	panicMu.Lock()
+	defer panicMu.Unlock()
+	return panicErr
+}
+
+// panicError logs the stack trace of the panic at debug level and returns it as an error.
+func panicError(r interface{}) error {
+	slog.Debug("tui panic", "panic", r, "stack", string(debug.Stack()))
+	return fmt.Errorf("tui crashed: %v", r)
+}
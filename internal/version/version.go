@@ -0,0 +1,71 @@
+package version
+
+import (
+	"fmt"
+	"regexp"
+	"runtime/debug"
+	"strconv"
+	"strings"
+)
+
+// Binary is the version of the signalhound binary, set when building with
+// -ldflags "-X sigs.k8s.io/signalhound/internal/version.Binary=v0.2.0". The
+// module version of the build is used when empty.
+var Binary string
+
+// versionPattern matches the major and minor numbers of a release, e.g. v1.32
+var versionPattern = regexp.MustCompile(`v?(\d+)\.(\d+)`)
+
+// Extract returns the major.minor release found in the text, e.g. "1.32" for
+// "v1.32" or "K8s 1.32.1", and an empty string when the text has none.
+func Extract(text string) string {
+	if matches := versionPattern.FindStringSubmatch(text); len(matches) >= 3 {
+		return fmt.Sprintf("%s.%s", matches[1], matches[2])
+	}
+	return ""
+}
+
+// Compare compares two dotted versions numerically, part by part, so 1.10 is
+// newer than 1.9. An optional v prefix is ignored, and missing or non numeric
+// parts count as 0. It returns 1 if v1 > v2, -1 if v1 < v2 and 0 if equal.
+func Compare(v1, v2 string) int {
+	parts1 := strings.Split(strings.TrimPrefix(strings.TrimSpace(v1), "v"), ".")
+	parts2 := strings.Split(strings.TrimPrefix(strings.TrimSpace(v2), "v"), ".")
+
+	for i := 0; i < max(len(parts1), len(parts2)); i++ {
+		var num1, num2 int
+		if i < len(parts1) {
+			num1, _ = strconv.Atoi(parts1[i])
+		}
+		if i < len(parts2) {
+			num2, _ = strconv.Atoi(parts2[i])
+		}
+
+		if num1 > num2 {
+			return 1
+		}
+		if num1 < num2 {
+			return -1
+		}
+	}
+
+	return 0
+}
+
+// UserAgent returns the User-Agent of the requests of signalhound, e.g.
+// signalhound/v0.2.0, or signalhound/dev for a build without a version.
+func UserAgent() string {
+	return "signalhound/" + binaryVersion()
+}
+
+// binaryVersion returns Binary, falling back to the module version of the
+// build and then to dev.
+func binaryVersion() string {
+	if Binary != "" {
+		return Binary
+	}
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+	return "dev"
+}
@@ -0,0 +1,67 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{name: "prefixed", text: "v1.32", expected: "1.32"},
+		{name: "not prefixed", text: "1.30", expected: "1.30"},
+		{name: "patch dropped", text: "v1.34.1", expected: "1.34"},
+		{name: "inside a label", text: "K8s Release 1.33 (current)", expected: "1.33"},
+		{name: "two digits minor", text: "v1.10", expected: "1.10"},
+		{name: "major only", text: "v1"},
+		{name: "garbage", text: "next release"},
+		{name: "empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Extract(tt.text))
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name     string
+		v1, v2   string
+		expected int
+	}{
+		{name: "numeric not lexical", v1: "1.10", v2: "1.9", expected: 1},
+		{name: "numeric not lexical reversed", v1: "1.9", v2: "1.10", expected: -1},
+		{name: "equal", v1: "1.32", v2: "1.32"},
+		{name: "v prefix ignored", v1: "v1.32", v2: "1.32"},
+		{name: "major first", v1: "2.0", v2: "1.99", expected: 1},
+		{name: "missing part is zero", v1: "1.32", v2: "1.32.0"},
+		{name: "extra patch is newer", v1: "1.32.1", v2: "1.32", expected: 1},
+		{name: "garbage part is zero", v1: "1.x", v2: "1.0"},
+		{name: "garbage against a version", v1: "latest", v2: "1.30", expected: -1},
+		{name: "both empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Compare(tt.v1, tt.v2))
+			assert.Equal(t, -tt.expected, Compare(tt.v2, tt.v1))
+		})
+	}
+}
+
+func TestUserAgent(t *testing.T) {
+	defer func(binary string) { Binary = binary }(Binary)
+
+	Binary = "v0.2.0"
+	assert.Equal(t, "signalhound/v0.2.0", UserAgent())
+
+	// test binaries have no module version
+	Binary = ""
+	assert.Equal(t, "signalhound/dev", UserAgent())
+}